@@ -0,0 +1,307 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Suffix of the directory with a file per season of every game seen that
+// season, written on every run so the season survives its sources going away.
+// Like the event revisions it sits next to the output directory (e.g.
+// ".dist-seasons/2025.json"), so cleaning or deploying dist leaves it alone.
+const seasonGamesDir = "-seasons"
+
+// SeasonTeam is a team's final record and games in an archived season
+type SeasonTeam struct {
+	Name     string
+	Slug     string
+	Wins     int
+	Losses   int
+	Meetings []Meeting
+}
+
+// Record returns the team's W-L record for the season
+func (t SeasonTeam) Record() string {
+	return fmt.Sprintf("%d-%d", t.Wins, t.Losses)
+}
+
+// SeasonSummary is a row on the archive index and the body of a season page
+type SeasonSummary struct {
+	Name   string
+	Slug   string
+	Wins   int
+	Losses int
+	Teams  []SeasonTeam
+}
+
+// Record returns the club's combined W-L record for the season
+func (s SeasonSummary) Record() string {
+	return fmt.Sprintf("%d-%d", s.Wins, s.Losses)
+}
+
+type ArchiveTemplateData struct {
+	PageTitle      string
+	UpdatedDisplay string
+	CurrentSeason  string
+	Seasons        []SeasonSummary // Index page
+	Season         *SeasonSummary  // Season page
+	StylesCSS      template.CSS
+}
+
+// seasonOf names the season a date falls in, e.g. "2025", or "2025-26" when
+// seasons start later in the year
+func seasonOf(date time.Time) string {
+	year := date.Year()
+	if date.Month() < seasonStartMonth {
+		year--
+	}
+	if seasonStartMonth == time.January {
+		return fmt.Sprintf("%d", year)
+	}
+	return fmt.Sprintf("%d-%02d", year, (year+1)%100)
+}
+
+// parseMonth reads a month as a name ("August", "aug") or a number ("8")
+func parseMonth(text string) (time.Month, error) {
+	text = strings.TrimSpace(text)
+	if n, err := strconv.Atoi(text); err == nil {
+		if n < 1 || n > 12 {
+			return time.January, fmt.Errorf("month %d is out of range", n)
+		}
+		return time.Month(n), nil
+	}
+	if len(text) >= 3 {
+		for month := time.January; month <= time.December; month++ {
+			if strings.HasPrefix(strings.ToLower(month.String()), strings.ToLower(text)) {
+				return month, nil
+			}
+		}
+	}
+	return time.January, fmt.Errorf("unknown month %q", text)
+}
+
+// activeSeason is the season shown on the main pages
+func activeSeason(now time.Time) string {
+	if currentSeason != "" {
+		return currentSeason
+	}
+	return seasonOf(now)
+}
+
+// assignSeasons fills in the season of games without a Season column value
+// from their date. Undated games belong to the current season. A Season
+// value that no game date falls in (say "Fall 2025" rather than "2025") is
+// warned about, since its games would quietly drop off the main pages.
+func assignSeasons(allGames []Game, current string) {
+	known := map[string]bool{current: true}
+	for i := range allGames {
+		if date := parseDateForSorting(allGames[i].Date); date.Year() != 2099 {
+			known[seasonOf(date)] = true
+		}
+	}
+
+	warned := make(map[string]bool)
+	for i := range allGames {
+		if season := allGames[i].Season; season != "" {
+			if !known[season] && !warned[season] {
+				addBuildWarning("Season %q matches no season by date (current is %q); its games only show in the archive", season, current)
+				warned[season] = true
+			}
+			continue
+		}
+		date := parseDateForSorting(allGames[i].Date)
+		if date.Year() == 2099 {
+			allGames[i].Season = current
+		} else {
+			allGames[i].Season = seasonOf(date)
+		}
+	}
+}
+
+// seasonGames returns the games of one season, in their original order
+func seasonGames(allGames []Game, season string) []Game {
+	var games []Game
+	for _, game := range allGames {
+		if game.Season == season {
+			games = append(games, game)
+		}
+	}
+	return games
+}
+
+// seasonNotes returns the notes dated in the season; undated notes stay current
+func seasonNotes(allNotes []Note, season string) []Note {
+	var notes []Note
+	for _, note := range allNotes {
+		date := parseDateForSorting(note.Date)
+		if date.Year() == 2099 || seasonOf(date) == season {
+			notes = append(notes, note)
+		}
+	}
+	return notes
+}
+
+// summarizeSeason tallies each team's games in a season, in team order
+func summarizeSeason(slug string, meetings []Meeting) SeasonSummary {
+	season := SeasonSummary{Name: slug, Slug: slug}
+	if len(meetings) > 0 && meetings[0].Season != "" {
+		season.Name = meetings[0].Season
+	}
+	bySlug := make(map[string]*SeasonTeam)
+	var slugs []string
+	for _, m := range meetings {
+		team := bySlug[m.TeamSlug]
+		if team == nil {
+			team = &SeasonTeam{Name: m.Team, Slug: m.TeamSlug}
+			bySlug[m.TeamSlug] = team
+			slugs = append(slugs, m.TeamSlug)
+		}
+		team.Meetings = append(team.Meetings, m)
+		switch m.Result {
+		case "W":
+			team.Wins++
+			season.Wins++
+		case "L":
+			team.Losses++
+			season.Losses++
+		}
+	}
+
+	// Current teams keep their sheet order; teams that no longer exist go last
+	order := func(slug string) int {
+		for _, team := range AllTeams {
+			if team.Slug == slug {
+				return team.Order
+			}
+		}
+		return len(AllTeams) + 1
+	}
+	sort.SliceStable(slugs, func(i, j int) bool {
+		return order(slugs[i]) < order(slugs[j])
+	})
+
+	for _, slug := range slugs {
+		team := bySlug[slug]
+		// Chronological within a season
+		sort.SliceStable(team.Meetings, func(i, j int) bool {
+			dateA, dateB := parseDateForSorting(team.Meetings[i].Date), parseDateForSorting(team.Meetings[j].Date)
+			if !dateA.Equal(dateB) {
+				return dateA.Before(dateB)
+			}
+			return parseTimeToMinutes(team.Meetings[i].Time) < parseTimeToMinutes(team.Meetings[j].Time)
+		})
+		season.Teams = append(season.Teams, *team)
+	}
+	return season
+}
+
+// generateArchive stores every season's games (including the current one, so
+// it's already archived once its sources disappear) and writes a page per
+// past season with final records, plus an index of past seasons. Stored games
+// that were never played and are gone from the sources aren't archived.
+func generateArchive(allGames []Game, current, seasonsDir, outputDir string) error {
+	err := os.MkdirAll(seasonsDir, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating seasons directory: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, game := range allGames {
+		if seen[game.Season] {
+			continue
+		}
+		seen[game.Season] = true
+
+		gamesPath := filepath.Join(seasonsDir, slugify(game.Season)+".json")
+		stored, err := loadMeetings(gamesPath)
+		if err != nil {
+			return err
+		}
+		err = saveMeetings(gamesPath, mergeMeetings(stored, seasonGames(allGames, game.Season)))
+		if err != nil {
+			return err
+		}
+	}
+
+	tmpl, err := template.New("archive").Parse(archiveTemplate)
+	if err != nil {
+		return fmt.Errorf("error parsing template: %v", err)
+	}
+
+	now := time.Now().UTC()
+	data := ArchiveTemplateData{
+		PageTitle:      "Lightning Past Seasons",
+		UpdatedDisplay: now.Format("1/2/06") + " at " + now.Format("3:04PM") + " UTC",
+		CurrentSeason:  current,
+		StylesCSS:      template.CSS(stylesCSS),
+	}
+
+	// Every stored season except the current one gets a page
+	entries, err := os.ReadDir(seasonsDir)
+	if err != nil {
+		return fmt.Errorf("error reading seasons directory: %v", err)
+	}
+	for _, entry := range entries {
+		slug, ok := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !ok || slug == slugify(current) {
+			continue
+		}
+		meetings, err := loadMeetings(filepath.Join(seasonsDir, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if len(meetings) == 0 {
+			continue
+		}
+		data.Seasons = append(data.Seasons, summarizeSeason(slug, meetings))
+	}
+
+	// Most recent season first
+	sort.Slice(data.Seasons, func(i, j int) bool {
+		return lastMeeting(data.Seasons[i]).After(lastMeeting(data.Seasons[j]))
+	})
+
+	err = executeTemplateToFile(tmpl, data, filepath.Join(outputDir, "index.html"))
+	if err != nil {
+		return err
+	}
+
+	for i := range data.Seasons {
+		season := &data.Seasons[i]
+		page := data
+		page.PageTitle = "Lightning " + season.Name + " Season"
+		page.Seasons = nil
+		page.Season = season
+
+		seasonDir := filepath.Join(outputDir, season.Slug)
+		err = os.MkdirAll(seasonDir, 0755)
+		if err != nil {
+			return fmt.Errorf("error creating season directory: %v", err)
+		}
+		err = executeTemplateToFile(tmpl, page, filepath.Join(seasonDir, "index.html"))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lastMeeting returns the date of the season's last game
+func lastMeeting(season SeasonSummary) time.Time {
+	var last time.Time
+	for _, team := range season.Teams {
+		if n := len(team.Meetings); n > 0 {
+			if date := parseDateForSorting(team.Meetings[n-1].Date); date.After(last) {
+				last = date
+			}
+		}
+	}
+	return last
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateArchiveDropsPhantomGames(t *testing.T) {
+	setupTestClub(t)
+	team := &AllTeams[0]
+	seasonsDir, dir := t.TempDir(), t.TempDir()
+
+	// Last run stored a game that has since been moved to another day
+	stored := []Meeting{
+		{Date: "Saturday, October 18, 2025", Time: "9:00 AM", Team: team.Name, TeamSlug: team.Slug, Opponent: "Storm", Season: "2025"},
+		{Date: "Saturday, September 13, 2025", Time: "9:00 AM", Team: team.Name, TeamSlug: team.Slug, Opponent: "Omaha Sky", Score: "W 40-31", Result: "W", Season: "2025"},
+	}
+	if err := saveMeetings(filepath.Join(seasonsDir, "2025.json"), stored); err != nil {
+		t.Fatal(err)
+	}
+
+	games := []Game{
+		{Team: team, Date: "Saturday, October 25, 2025", Time: "9:00 AM", Opponent: "Storm", Score: "L 28-30", Result: "L", Season: "2025"},
+	}
+	if err := generateArchive(games, "2026", seasonsDir, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	meetings, err := loadMeetings(filepath.Join(seasonsDir, "2025.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dates []string
+	for _, m := range meetings {
+		dates = append(dates, m.Date+" "+m.Opponent)
+	}
+	want := []string{"Saturday, October 25, 2025 Storm", "Saturday, September 13, 2025 Omaha Sky"}
+	if strings.Join(dates, "|") != strings.Join(want, "|") {
+		t.Errorf("archived %q, want %q", dates, want)
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, "2025", "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(page), "October 18") {
+		t.Error("season page lists the game that was moved")
+	}
+
+	// Stored games stay out of the published pages
+	filepath.WalkDir(dir, func(path string, _ os.DirEntry, _ error) error {
+		if strings.HasSuffix(path, ".json") {
+			t.Errorf("%s is in the output directory", path)
+		}
+		return nil
+	})
+}
+
+func TestGenerateArchiveCountsDoubleheaders(t *testing.T) {
+	setupTestClub(t)
+	team := &AllTeams[0]
+	seasonsDir, dir := t.TempDir(), t.TempDir()
+	date := "Saturday, October 18, 2025"
+
+	games := []Game{
+		{Team: team, Date: date, Time: "9:00 AM", Opponent: "Storm", Score: "W 40-31", Result: "W", Season: "2025", SourceID: "tm-h1-12"},
+		{Team: team, Date: date, Time: "3:00 PM", Opponent: "Storm", Score: "W 35-30", Result: "W", Season: "2025", SourceID: "tm-h1-30"},
+		{Team: team, Date: "Sunday, October 19, 2025", Time: "10:00 AM", Opponent: "Hawks", Score: "L 20-22", Result: "L", Season: "2025"},
+		{Team: team, Date: "Sunday, October 19, 2025", Time: "2:00 PM", Opponent: "Hawks", Score: "L 18-30", Result: "L", Season: "2025"},
+	}
+	// Run twice: the second run merges the stored season with the same games
+	for run := 0; run < 2; run++ {
+		if err := generateArchive(games, "2026", seasonsDir, dir); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	meetings, err := loadMeetings(filepath.Join(seasonsDir, "2025.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	season := summarizeSeason("2025", meetings)
+	if season.Record() != "2-2" || len(season.Teams) != 1 || season.Teams[0].Record() != "2-2" {
+		t.Errorf("season record %s, teams %+v; want 2-2", season.Record(), season.Teams)
+	}
+}
+
+func TestSeasonOf(t *testing.T) {
+	defer func(month time.Month) { seasonStartMonth = month }(seasonStartMonth)
+
+	tests := []struct {
+		start time.Month
+		date  time.Time
+		want  string
+	}{
+		{time.January, time.Date(2025, time.October, 18, 0, 0, 0, 0, time.UTC), "2025"},
+		{time.January, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), "2026"},
+		{time.August, time.Date(2025, time.October, 18, 0, 0, 0, 0, time.UTC), "2025-26"},
+		{time.August, time.Date(2026, time.March, 7, 0, 0, 0, 0, time.UTC), "2025-26"},
+		{time.August, time.Date(2026, time.August, 1, 0, 0, 0, 0, time.UTC), "2026-27"},
+	}
+	for _, test := range tests {
+		seasonStartMonth = test.start
+		if got := seasonOf(test.date); got != test.want {
+			t.Errorf("seasonOf(%s) starting in %s = %q, want %q", test.date.Format("2006-01-02"), test.start, got, test.want)
+		}
+	}
+}
+
+func TestParseMonth(t *testing.T) {
+	tests := map[string]time.Month{"8": time.August, "August": time.August, "aug": time.August, " sept ": time.September, "12": time.December, "Mar": time.March}
+	for text, want := range tests {
+		if got, err := parseMonth(text); err != nil || got != want {
+			t.Errorf("parseMonth(%q) = %v, %v; want %v", text, got, err, want)
+		}
+	}
+	for _, bad := range []string{"0", "13", "ju", "Augtober", ""} {
+		if _, err := parseMonth(bad); err == nil {
+			t.Errorf("parseMonth(%q) should fail", bad)
+		}
+	}
+}
+
+func TestAssignSeasons(t *testing.T) {
+	setupTestClub(t)
+	defer func(month time.Month, season string) { seasonStartMonth, currentSeason = month, season }(seasonStartMonth, currentSeason)
+	seasonStartMonth = time.August
+	team := &AllTeams[0]
+
+	games := []Game{
+		{Team: team, Date: "Saturday, October 18, 2025"},
+		{Team: team, Date: "TBD"},
+		{Team: team, Date: "Saturday, March 8, 2025", Season: "2024-25"},
+		{Team: team, Date: "Saturday, October 25, 2025", Season: "Fall 2025"},
+		{Team: team, Date: "Sunday, October 26, 2025", Season: "Fall 2025"},
+	}
+	now := time.Date(2025, time.November, 1, 12, 0, 0, 0, time.UTC)
+	current := activeSeason(now)
+	assignSeasons(games, current)
+
+	var seasons []string
+	for _, game := range games {
+		seasons = append(seasons, game.Season)
+	}
+	want := []string{"2025-26", "2025-26", "2024-25", "Fall 2025", "Fall 2025"}
+	if strings.Join(seasons, "|") != strings.Join(want, "|") {
+		t.Errorf("seasons = %q, want %q", seasons, want)
+	}
+	if len(BuildWarnings) != 1 || !strings.Contains(BuildWarnings[0], `"Fall 2025"`) {
+		t.Errorf("warnings = %q, want one about Fall 2025", BuildWarnings)
+	}
+
+	// -season picks the season instead of today's date
+	currentSeason = "Fall 2025"
+	if got := activeSeason(now); got != "Fall 2025" {
+		t.Errorf("activeSeason with -season = %q", got)
+	}
+	BuildWarnings = nil
+	assignSeasons(games, activeSeason(now))
+	if len(BuildWarnings) != 0 {
+		t.Errorf("warned about the chosen season: %q", BuildWarnings)
+	}
+}
@@ -27,4 +27,4 @@ for file in static/*; do
   fi
 done
 
-go run generate.go
+go run .
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ComboFilter is a family's combined schedule for several teams, addressable
+// as /combo/<slug>+<slug>/. Overlapping games are flagged since one family
+// can't be in two gyms at once.
+func ComboFilter(teams []*Team) Filter {
+	sorted := append([]*Team(nil), teams...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Order < sorted[j].Order
+	})
+
+	var names, slugs []string
+	for _, team := range sorted {
+		names = append(names, team.Name)
+		slugs = append(slugs, team.Slug)
+	}
+
+	return Filter{
+		Title:         strings.Join(names, " + "),
+		Path:          "/combo/" + strings.Join(slugs, "+") + "/",
+		Teams:         sorted,
+		FlagConflicts: true,
+	}
+}
+
+// parseComboSlugs resolves "10u-blue+12u-gold" to teams
+func parseComboSlugs(slugs string) ([]*Team, error) {
+	var teams []*Team
+	seen := make(map[string]bool)
+	for _, slug := range strings.Split(slugs, "+") {
+		slug = strings.TrimSpace(slug)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+
+		team := findTeamBySlug(slug)
+		if team == nil {
+			return nil, fmt.Errorf("unknown team %q", slug)
+		}
+		teams = append(teams, team)
+	}
+	if len(teams) < 2 {
+		return nil, fmt.Errorf("a combination needs at least two teams")
+	}
+	return teams, nil
+}
+
+func findTeamBySlug(slug string) *Team {
+	for i := range AllTeams {
+		if AllTeams[i].Slug == slug {
+			return &AllTeams[i]
+		}
+	}
+	return nil
+}
+
+// fetchFamilies reads the team combinations to precompute from the Families
+// tab (one row per family, comma-separated team names in the Teams column)
+func fetchFamilies() ([]Filter, error) {
+	headers, records, err := fetchSheetTab("Families")
+	if err != nil {
+		return nil, err
+	}
+
+	var combos []Filter
+	seen := make(map[string]bool)
+	for _, record := range records {
+		teamNames := getCellValue(headers, record, "Teams")
+
+		// Skip rows with missing data
+		if teamNames == "" {
+			continue
+		}
+
+		var teams []*Team
+		for _, name := range strings.Split(teamNames, ",") {
+			team := findTeamByName(strings.TrimSpace(name))
+			if team == nil {
+				addBuildWarning("unknown team %q in Families sheet", strings.TrimSpace(name))
+				continue
+			}
+			teams = append(teams, team)
+		}
+		if len(teams) < 2 {
+			continue
+		}
+
+		// Several families may follow the same teams; generate each combination once
+		combo := ComboFilter(teams)
+		if seen[combo.Path] {
+			continue
+		}
+		seen[combo.Path] = true
+		combos = append(combos, combo)
+	}
+
+	return combos, nil
+}
+
+// comboHandler serves combination pages and feeds on demand in server mode,
+// falling back to the generated static files for every other path
+func comboHandler(allGames []Game, allNotes []Note, distDir string) http.Handler {
+	static := http.FileServer(http.Dir(distDir))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.Path, "/combo/")
+		if !ok {
+			static.ServeHTTP(w, r)
+			return
+		}
+
+		slugs, file, _ := strings.Cut(rest, "/")
+		teams, err := parseComboSlugs(slugs)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		combo := ComboFilter(teams)
+
+		// Redirect to the canonical team order so each combination has one URL
+		if !strings.HasPrefix(r.URL.Path, combo.Path) {
+			http.Redirect(w, r, combo.Path+file, http.StatusMovedPermanently)
+			return
+		}
+
+		switch file {
+		case "", "index.html":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			err = renderHTML(w, allGames, allNotes, combo)
+			if err != nil {
+				fmt.Printf("Error rendering %s: %v\n", r.URL.Path, err)
+			}
+		case "schedule.ics":
+			output, err := renderICalendar(allGames, allNotes, combo)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+			w.Write(output)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// serve runs the schedule web server on addr
+func serve(addr string, allGames []Game, allNotes []Note, distDir string) error {
+	absDir, err := filepath.Abs(distDir)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("🌐 Serving %s on %s\n", absDir, addr)
+	return http.ListenAndServe(addr, comboHandler(allGames, allNotes, absDir))
+}
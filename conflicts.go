@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How long we assume a game lasts when checking for overlaps
+const gameDuration = 1 * time.Hour
+
+// Travel time assumed between two different venues without a Travel row
+const defaultTravelTime = 30 * time.Minute
+
+// Travel times between venues keyed by "ABBREV|ABBREV" (both orders)
+var TravelTimes = map[string]time.Duration{}
+
+// Group is a set of teams followed by the same people (a coach running two
+// teams, a family with kids on several) whose games must not collide
+type Group struct {
+	Name  string
+	Teams []*Team
+}
+
+// Conflict is a pair of games a group can't both make: they overlap, or the
+// gap between them is shorter than the travel time between the venues
+type Conflict struct {
+	Group  string
+	First  *Game
+	Second *Game
+	Gap    time.Duration // From the end of First to the start of Second; negative if they overlap
+	Travel time.Duration // Time needed to get from First's venue to Second's
+}
+
+// IsOverlap reports whether the games are on at the same time
+func (c Conflict) IsOverlap() bool {
+	return c.Gap < 0
+}
+
+// Warning describes the conflict from the point of view of one of its games
+func (c Conflict) Warning(game *Game) string {
+	other := c.Second
+	if game == c.Second {
+		other = c.First
+	}
+
+	what := "Overlaps"
+	if !c.IsOverlap() {
+		what = fmt.Sprintf("Only %d min (need %d) to reach", int(c.Gap.Minutes()), int(c.Travel.Minutes()))
+	}
+	warning := fmt.Sprintf("%s %s at %s", what, other.Team.Name, formatTime(other.Time))
+	if c.Group != "" {
+		warning = c.Group + ": " + warning
+	}
+	return warning
+}
+
+// String describes the conflict for the conflicts report
+func (c Conflict) String() string {
+	what := "overlaps"
+	if !c.IsOverlap() {
+		what = fmt.Sprintf("is followed %d min later (travel needs %d) by", int(c.Gap.Minutes()), int(c.Travel.Minutes()))
+	}
+	return fmt.Sprintf("%s: %s %s %s", c.Group, describeGame(c.First), what, describeGame(c.Second))
+}
+
+// describeGame renders e.g. "10U Blue vs Sky (Sat Oct 18 10AM, RA)"
+func describeGame(game *Game) string {
+	where := "TBD"
+	if game.Location != nil {
+		where = game.Location.Abbrev
+	}
+	when := game.Date
+	if date := parseDateForSorting(game.Date); date.Year() != 2099 {
+		when = date.Format("Mon Jan 2")
+	}
+	return fmt.Sprintf("%s vs %s (%s %s, %s)", game.Team.Name, game.Opponent, when, formatTime(game.Time), where)
+}
+
+// travelTime returns how long it takes to get from one venue to another
+func travelTime(from, to *Location) time.Duration {
+	if from != nil && to != nil && from == to {
+		return 0
+	}
+	if from != nil && to != nil {
+		if travel, ok := TravelTimes[from.Abbrev+"|"+to.Abbrev]; ok {
+			return travel
+		}
+	}
+	return defaultTravelTime
+}
+
+// findConflicts checks every pair of timed games belonging to different
+// teams for overlaps and transitions shorter than the travel time. Possible
+// bracket games are left out until they're on: most never get played.
+func findConflicts(group string, games []*Game) []Conflict {
+	type timedGame struct {
+		game  *Game
+		start time.Time
+	}
+	var timed []timedGame
+	for _, game := range games {
+		if game.Condition != "" {
+			continue
+		}
+		if start, ok := game.Start(); ok {
+			timed = append(timed, timedGame{game, start})
+		}
+	}
+	sort.SliceStable(timed, func(i, j int) bool {
+		return timed[i].start.Before(timed[j].start)
+	})
+
+	var conflicts []Conflict
+	for i := range timed {
+		for j := i + 1; j < len(timed); j++ {
+			a, b := timed[i], timed[j]
+			if a.game.Team.Slug == b.game.Team.Slug {
+				continue
+			}
+
+			gap := b.start.Sub(a.start.Add(gameDuration))
+			travel := travelTime(a.game.Location, b.game.Location)
+			if gap >= travel {
+				continue
+			}
+			conflicts = append(conflicts, Conflict{
+				Group:  group,
+				First:  a.game,
+				Second: b.game,
+				Gap:    gap,
+				Travel: travel,
+			})
+		}
+	}
+	return conflicts
+}
+
+// findOverlaps returns a warning for every game in the list that conflicts
+// with a game of a different team in the same list, keyed by the game
+func findOverlaps(games []*Game) map[*Game]string {
+	warnings := make(map[*Game]string)
+	for _, conflict := range findConflicts("", games) {
+		addWarning(warnings, conflict.First, conflict.Warning(conflict.First))
+		addWarning(warnings, conflict.Second, conflict.Warning(conflict.Second))
+	}
+	return warnings
+}
+
+func addWarning(warnings map[*Game]string, game *Game, warning string) {
+	if existing := warnings[game]; existing != "" {
+		warning = existing + "; " + warning
+	}
+	warnings[game] = warning
+}
+
+// analyzeConflicts checks each group's games and records a warning on every
+// game involved, so the badge shows wherever the game is listed
+func analyzeConflicts(allGames []Game, groups []Group) []Conflict {
+	var conflicts []Conflict
+	for _, group := range groups {
+		var games []*Game
+		for i := range allGames {
+			for _, team := range group.Teams {
+				if allGames[i].Team.Slug == team.Slug {
+					games = append(games, &allGames[i])
+					break
+				}
+			}
+		}
+
+		for _, conflict := range findConflicts(group.Name, games) {
+			conflict.First.Warnings = append(conflict.First.Warnings, conflict.Warning(conflict.First))
+			conflict.Second.Warnings = append(conflict.Second.Warnings, conflict.Warning(conflict.Second))
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts
+}
+
+// writeConflictsReport writes one line per conflict to a plain-text report
+func writeConflictsReport(conflicts []Conflict, outputFile string) error {
+	var report strings.Builder
+	report.WriteString(fmt.Sprintf("Schedule conflicts as of %s\n\n", time.Now().UTC().Format("1/2/06 3:04PM UTC")))
+	if len(conflicts) == 0 {
+		report.WriteString("No conflicts found.\n")
+	}
+	for _, conflict := range conflicts {
+		report.WriteString(conflict.String() + "\n")
+	}
+
+	err := os.WriteFile(outputFile, []byte(report.String()), 0644)
+	if err != nil {
+		return fmt.Errorf("error writing conflicts report: %v", err)
+	}
+	return nil
+}
+
+// fetchGroups reads coach/team groups from the Groups tab (Name, and
+// comma-separated team names in Teams)
+func fetchGroups() ([]Group, error) {
+	headers, records, err := fetchSheetTab("Groups")
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []Group
+	for _, record := range records {
+		name := getCellValue(headers, record, "Name")
+		teamNames := getCellValue(headers, record, "Teams")
+
+		// Skip rows with missing data
+		if name == "" || teamNames == "" {
+			continue
+		}
+
+		group := Group{Name: name}
+		for _, teamName := range strings.Split(teamNames, ",") {
+			team := findTeamByName(strings.TrimSpace(teamName))
+			if team == nil {
+				addBuildWarning("unknown team %q in group %s", strings.TrimSpace(teamName), name)
+				continue
+			}
+			group.Teams = append(group.Teams, team)
+		}
+		if len(group.Teams) > 1 {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups, nil
+}
+
+// fetchTravelTimes reads driving times between venues from the Travel tab
+// (From and To location abbreviations, Minutes)
+func fetchTravelTimes() (map[string]time.Duration, error) {
+	headers, records, err := fetchSheetTab("Travel")
+	if err != nil {
+		return nil, err
+	}
+
+	travel := make(map[string]time.Duration)
+	for _, record := range records {
+		from := getCellValue(headers, record, "From")
+		to := getCellValue(headers, record, "To")
+		minutes, err := strconv.Atoi(getCellValue(headers, record, "Minutes"))
+
+		// Skip rows with missing data
+		if from == "" || to == "" || err != nil {
+			continue
+		}
+
+		travel[from+"|"+to] = time.Duration(minutes) * time.Minute
+		if _, ok := travel[to+"|"+from]; !ok {
+			travel[to+"|"+from] = time.Duration(minutes) * time.Minute
+		}
+	}
+
+	return travel, nil
+}
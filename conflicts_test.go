@@ -0,0 +1,131 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFindConflicts(t *testing.T) {
+	setupTestClub(t)
+	defer func() { TravelTimes = map[string]time.Duration{} }()
+	gold, blue := &AllTeams[0], &AllTeams[1]
+	ralston := &AllLocations[0]
+	westside := &Location{Abbrev: "WS", Name: "Westside"}
+	date := "Saturday, October 18, 2025"
+
+	game := func(team *Team, clock string, location *Location) *Game {
+		return &Game{Team: team, Date: date, Time: clock, Location: location, Opponent: "Storm"}
+	}
+
+	tests := []struct {
+		name        string
+		travel      map[string]time.Duration
+		first, next *Game
+		wantGap     time.Duration // Only checked when a conflict is expected
+		wantTravel  time.Duration
+		want        bool
+	}{
+		{"overlap at one venue", nil, game(gold, "9:00 AM", ralston), game(blue, "9:30 AM", ralston), -30 * time.Minute, 0, true},
+		{"back to back at one venue", nil, game(gold, "9:00 AM", ralston), game(blue, "10:00 AM", ralston), 0, 0, false},
+		{"too little time to drive", nil, game(gold, "9:00 AM", ralston), game(blue, "10:20 AM", westside), 20 * time.Minute, defaultTravelTime, true},
+		{"enough time to drive", nil, game(gold, "9:00 AM", ralston), game(blue, "10:30 AM", westside), 0, 0, false},
+		{"Travel row shorter than the default", map[string]time.Duration{"RA|WS": 15 * time.Minute}, game(gold, "9:00 AM", ralston), game(blue, "10:20 AM", westside), 0, 0, false},
+		{"Travel row longer than the default", map[string]time.Duration{"WS|RA": 45 * time.Minute}, game(gold, "9:00 AM", westside), game(blue, "10:40 AM", ralston), 40 * time.Minute, 45 * time.Minute, true},
+		{"unknown venue needs the default", nil, game(gold, "9:00 AM", nil), game(blue, "10:00 AM", nil), 0, defaultTravelTime, true},
+		{"same team", nil, game(gold, "9:00 AM", ralston), game(gold, "9:30 AM", ralston), 0, 0, false},
+		{"TBD time", nil, game(gold, "9:00 AM", ralston), game(blue, "TBD", ralston), 0, 0, false},
+	}
+	for _, test := range tests {
+		TravelTimes = test.travel
+		// Order in the list doesn't matter; conflicts are found in time order
+		conflicts := findConflicts("Coach Kim", []*Game{test.next, test.first})
+		if !test.want {
+			if len(conflicts) != 0 {
+				t.Errorf("%s: unexpected conflict %s", test.name, conflicts[0])
+			}
+			continue
+		}
+		if len(conflicts) != 1 {
+			t.Errorf("%s: got %d conflicts, want 1", test.name, len(conflicts))
+			continue
+		}
+		c := conflicts[0]
+		if c.First != test.first || c.Second != test.next || c.Gap != test.wantGap || c.Travel != test.wantTravel {
+			t.Errorf("%s: got first %s, gap %v, travel %v; want gap %v, travel %v", test.name, c.First.Time, c.Gap, c.Travel, test.wantGap, test.wantTravel)
+		}
+	}
+}
+
+func TestFindConflictsSkipsPossibleBracketGames(t *testing.T) {
+	setupTestClub(t)
+	gold, blue := &AllTeams[0], &AllTeams[1]
+	ralston := &AllLocations[0]
+	date := "Sunday, October 19, 2025"
+
+	games := []*Game{
+		{Team: gold, Date: date, Time: "9:00 AM", Location: ralston, Opponent: "Storm"},
+		{Team: blue, Date: date, Time: "9:00 AM", Location: ralston, Opponent: "Winner of Game 12", Condition: "If 10U Blue wins Game 12"},
+	}
+	if conflicts := findConflicts("Coach Kim", games); len(conflicts) != 0 {
+		t.Errorf("got %d conflicts with a possible bracket game, want none", len(conflicts))
+	}
+}
+
+func TestConflictWarnings(t *testing.T) {
+	setupTestClub(t)
+	gold, blue := &AllTeams[0], &AllTeams[1]
+	ralston := &AllLocations[0]
+	westside := &Location{Abbrev: "WS", Name: "Westside"}
+	date := "Saturday, October 18, 2025"
+
+	allGames := []Game{
+		{Team: gold, Date: date, Time: "9:00 AM", Location: ralston, Opponent: "Storm"},
+		{Team: blue, Date: date, Time: "9:30 AM", Location: ralston, Opponent: "Hawks"},
+		{Team: blue, Date: date, Time: "11:10 AM", Location: westside, Opponent: "Sky"},
+	}
+	conflicts := analyzeConflicts(allGames, []Group{{Name: "Coach Kim", Teams: []*Team{gold, blue}}})
+	if len(conflicts) != 1 {
+		t.Fatalf("got %d conflicts, want 1: %v", len(conflicts), conflicts)
+	}
+	if got := strings.Join(allGames[0].Warnings, "; "); got != "Coach Kim: Overlaps 10U Blue at 9:30AM" {
+		t.Errorf("gold warning = %q", got)
+	}
+	if got := strings.Join(allGames[1].Warnings, "; "); got != "Coach Kim: Overlaps 12U Gold at 9AM" {
+		t.Errorf("blue warning = %q", got)
+	}
+	if len(allGames[2].Warnings) != 0 {
+		t.Errorf("same team's next game got warnings %q", allGames[2].Warnings)
+	}
+}
+
+func TestFetchTravelTimes(t *testing.T) {
+	Sheet = fakeSheet{"Travel": {
+		{"From", "To", "Minutes"},
+		{"RA", "WS", "25"},
+		{"WS", "MHF", "600"},
+		{"MHF", "WS", "540"},
+		{"RA", "", "10"},
+		{"RA", "MHF", "far"},
+	}}
+	defer func(sheet SheetBackend) { Sheet = sheet }(Sheet)
+
+	travel, err := fetchTravelTimes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]time.Duration{
+		"RA|WS":  25 * time.Minute,
+		"WS|RA":  25 * time.Minute, // The reverse trip unless listed
+		"WS|MHF": 600 * time.Minute,
+		"MHF|WS": 540 * time.Minute, // Listed rows win over the reverse
+	}
+	if len(travel) != len(want) {
+		t.Errorf("got %v, want %v", travel, want)
+	}
+	for key, minutes := range want {
+		if travel[key] != minutes {
+			t.Errorf("travel[%s] = %v, want %v", key, travel[key], minutes)
+		}
+	}
+}
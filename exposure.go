@@ -0,0 +1,295 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Matches the event ID in Exposure Events URLs, e.g. ".../219842/fall-classic/..."
+var exposureEventIDRegex = regexp.MustCompile(`exposureevents\.com/(\d+)`)
+
+// exposureGame is one game read from an Exposure Events page, before we
+// know which side is ours
+type exposureGame struct {
+	ID        string
+	Start     time.Time
+	HasTime   bool
+	Venue     string
+	Court     string
+	Away      string
+	AwayScore string
+	Home      string
+	HomeScore string
+	Division  string
+}
+
+// scrapeExposureSchedule reads a team's games from an Exposure Events
+// schedule page. Games come from the page's schema.org SportsEvent data when
+// present, otherwise from schedule tables mapped by their header row.
+func scrapeExposureSchedule(team *Team, url string) ([]Game, error) {
+	doc, err := fetchSchedulePage(team, url)
+	if err != nil {
+		return nil, err
+	}
+
+	eventID := ""
+	if match := exposureEventIDRegex.FindStringSubmatch(url); match != nil {
+		eventID = match[1]
+	}
+	tournament := strings.TrimSpace(doc.Find("h1").First().Text())
+	if tournament == "" {
+		tournament = strings.TrimSpace(strings.Split(doc.Find("title").First().Text(), "|")[0])
+	}
+
+	rows := exposureStructuredGames(doc)
+	if len(rows) == 0 {
+		rows = exposureTableGames(doc)
+	}
+
+	// A page we could read but found no games on usually means the layout changed
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("parsed %s page but found no games (%s)", team.Name, url)
+	}
+
+	var games []Game
+	unmatched := make(map[string]bool)
+	for _, row := range rows {
+		for _, name := range []string{row.Away, row.Home} {
+			if looksLikeOurs(name) && !unmatched[nameKey(name)] {
+				unmatched[nameKey(name)] = true
+				addBuildWarning("%s page lists %q, which matches no team name or alias (%s)", team.Name, name, url)
+			}
+		}
+
+		opponent, homeAway, ours, theirs := "", "", "", ""
+		switch {
+		case team.MatchesName(row.Away):
+			opponent, homeAway, ours, theirs = row.Home, "Away", row.AwayScore, row.HomeScore
+		case team.MatchesName(row.Home):
+			opponent, homeAway, ours, theirs = row.Away, "Home", row.HomeScore, row.AwayScore
+		default:
+			continue
+		}
+		score, result := gameScore(ours, theirs)
+
+		timeStr := "TBD"
+		if row.HasTime {
+			timeStr = row.Start.Format("3:04 PM")
+		}
+
+		loc, courtGymInfo := findLocationByName(row.Venue)
+		if courtGymInfo == "" {
+			courtGymInfo = row.Court
+		}
+
+		id := row.ID
+		if id == "" {
+			id = row.Start.Format("20060102-1504") + "-" + slugify(opponent)
+		}
+
+		games = append(games, Game{
+			Team:         team,
+			Date:         row.Start.Format("Monday, January 2, 2006"),
+			Time:         timeStr,
+			Location:     loc,
+			CourtGymInfo: courtGymInfo,
+			Opponent:     opponent,
+			HomeAway:     homeAway,
+			Score:        score,
+			Result:       result,
+			Tournament:   tournament,
+			Division:     row.Division,
+			SourceID:     "ee-" + eventID + "-" + slugify(id),
+		})
+	}
+
+	if len(games) == 0 {
+		return nil, fmt.Errorf("found %d games on %s page but none for the team (%s)", len(rows), team.Name, url)
+	}
+
+	return games, nil
+}
+
+// exposureStructuredGames reads games from the page's JSON-LD SportsEvent data
+func exposureStructuredGames(doc *goquery.Document) []exposureGame {
+	var games []exposureGame
+	doc.Find(`script[type="application/ld+json"]`).Each(func(_ int, script *goquery.Selection) {
+		var data any
+		if err := json.Unmarshal([]byte(script.Text()), &data); err != nil {
+			return
+		}
+		walkJSONObjects(data, func(obj map[string]any) {
+			if kind, _ := obj["@type"].(string); kind != "SportsEvent" {
+				return
+			}
+			start, hasTime, ok := parseExposureTime(jsonString(obj["startDate"]))
+			if !ok {
+				return
+			}
+			game := exposureGame{
+				ID:        jsonString(obj["identifier"]),
+				Start:     start,
+				HasTime:   hasTime,
+				Away:      jsonName(obj["awayTeam"]),
+				Home:      jsonName(obj["homeTeam"]),
+				AwayScore: jsonString(obj["awayScore"]),
+				HomeScore: jsonString(obj["homeScore"]),
+			}
+			if location, ok := obj["location"].(map[string]any); ok {
+				game.Venue = jsonString(location["name"])
+			}
+			if game.Away != "" && game.Home != "" {
+				games = append(games, game)
+			}
+		})
+	})
+	return games
+}
+
+// exposureTableGames reads games from schedule tables with a header row
+// naming at least Date, Away (or Visitor) and Home
+func exposureTableGames(doc *goquery.Document) []exposureGame {
+	var games []exposureGame
+	doc.Find("table").Each(func(_ int, table *goquery.Selection) {
+		columns := map[string]int{}
+		table.Find("tr").Each(func(_ int, row *goquery.Selection) {
+			if row.Find("th").Length() > 1 {
+				columns = map[string]int{}
+				row.Children().Each(func(i int, cell *goquery.Selection) {
+					label := strings.ToLower(strings.Join(strings.Fields(cell.Text()), " "))
+					switch {
+					case label == "date" || label == "day":
+						columns["date"] = i
+					case label == "time":
+						columns["time"] = i
+					case label == "away" || label == "visitor":
+						columns["away"] = i
+					case label == "home":
+						columns["home"] = i
+					case strings.Contains(label, "score") && (strings.Contains(label, "away") || strings.Contains(label, "visitor")):
+						columns["awayScore"] = i
+					case strings.Contains(label, "score") && strings.Contains(label, "home"):
+						columns["homeScore"] = i
+					case label == "venue" || label == "location" || label == "gym":
+						columns["venue"] = i
+					case label == "court":
+						columns["court"] = i
+					case label == "division":
+						columns["division"] = i
+					case label == "game" || label == "game #" || label == "#":
+						columns["game"] = i
+					}
+				})
+				return
+			}
+
+			_, hasDate := columns["date"]
+			_, hasAway := columns["away"]
+			_, hasHome := columns["home"]
+			if !hasDate || !hasAway || !hasHome {
+				return
+			}
+
+			cells := row.Find("td")
+			cell := func(column string) string {
+				i, ok := columns[column]
+				if !ok || i >= cells.Length() {
+					return ""
+				}
+				return strings.Join(strings.Fields(cells.Eq(i).Text()), " ")
+			}
+
+			start, hasTime, ok := parseExposureTime(strings.TrimSpace(cell("date") + " " + cell("time")))
+			if !ok {
+				return
+			}
+			games = append(games, exposureGame{
+				ID:        cell("game"),
+				Start:     start,
+				HasTime:   hasTime,
+				Venue:     cell("venue"),
+				Court:     cell("court"),
+				Away:      cell("away"),
+				AwayScore: cell("awayScore"),
+				Home:      cell("home"),
+				HomeScore: cell("homeScore"),
+				Division:  cell("division"),
+			})
+		})
+	})
+	return games
+}
+
+// parseExposureTime reads the date formats Exposure Events uses, from ISO
+// timestamps in structured data to "Sat, Oct 18 9:00 AM" in tables. Times
+// are wall-clock times at the venue; offsets are dropped.
+func parseExposureTime(text string) (t time.Time, hasTime, ok bool) {
+	text = strings.Join(strings.Fields(text), " ")
+	timed := []string{
+		"2006-01-02T15:04:05Z07:00",
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+		"Monday, January 2, 2006 3:04 PM",
+		"Mon, Jan 2, 2006 3:04 PM",
+		"1/2/2006 3:04 PM",
+		"1/2/06 3:04 PM",
+	}
+	for _, layout := range timed {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			return time.Date(parsed.Year(), parsed.Month(), parsed.Day(), parsed.Hour(), parsed.Minute(), 0, 0, time.UTC), true, true
+		}
+	}
+	dateOnly := []string{
+		"2006-01-02",
+		"Monday, January 2, 2006",
+		"Mon, Jan 2, 2006",
+		"1/2/2006",
+		"1/2/06",
+	}
+	for _, layout := range dateOnly {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			return parsed, false, true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+// walkJSONObjects calls fn for every object nested anywhere in decoded JSON
+func walkJSONObjects(data any, fn func(map[string]any)) {
+	switch v := data.(type) {
+	case map[string]any:
+		fn(v)
+		for _, child := range v {
+			walkJSONObjects(child, fn)
+		}
+	case []any:
+		for _, child := range v {
+			walkJSONObjects(child, fn)
+		}
+	}
+}
+
+// jsonString renders a decoded JSON scalar as text
+func jsonString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return fmt.Sprintf("%g", v)
+	}
+	return ""
+}
+
+// jsonName returns the name of a schema.org object, or the value itself if
+// it's just a string
+func jsonName(value any) string {
+	if obj, ok := value.(map[string]any); ok {
+		return jsonString(obj["name"])
+	}
+	return jsonString(value)
+}
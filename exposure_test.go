@@ -0,0 +1,125 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fixtureServer serves saved pages from testdata, picked by the "fixture"
+// query parameter, so scrapers see a realistic URL
+func fixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, filepath.Join("testdata", filepath.Base(r.URL.Query().Get("fixture"))))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+type wantGame struct {
+	date, time, opponent, homeAway, score, location, court, sourceID string
+}
+
+func checkGames(t *testing.T, games []Game, want []wantGame) {
+	t.Helper()
+	if len(games) != len(want) {
+		for _, game := range games {
+			t.Logf("got %s %s vs %s", game.Date, game.Time, game.Opponent)
+		}
+		t.Fatalf("got %d games, want %d", len(games), len(want))
+	}
+	for i, w := range want {
+		game := games[i]
+		location := ""
+		if game.Location != nil {
+			location = game.Location.Name
+		}
+		got := wantGame{game.Date, game.Time, game.Opponent, game.HomeAway, game.Score, location, game.CourtGymInfo, game.SourceID}
+		if got != w {
+			t.Errorf("game %d:\n got %+v\nwant %+v", i, got, w)
+		}
+	}
+}
+
+func TestScrapeExposureStructuredData(t *testing.T) {
+	setupTestClub(t)
+	srv := fixtureServer(t)
+	team := &AllTeams[0]
+
+	games, err := scrapeExposureSchedule(team, srv.URL+"/basketball.exposureevents.com/219842/fall-hoops/schedule?fixture=exposure-jsonld.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkGames(t, games, []wantGame{
+		{"Saturday, October 18, 2025", "9:00 AM", "Omaha Sky", "Away", "W 41-38", "Ralston Arena", "Court 2", "ee-219842-5012"},
+		{"Saturday, October 18, 2025", "2:30 PM", "Storm Elite", "Home", "", "", "", "ee-219842-5020"},
+		{"Sunday, October 19, 2025", "TBD", "KC Hawks", "Away", "", "", "", "ee-219842-20251019-0000-kc-hawks"},
+	})
+	if games[0].Tournament != "Fall Hoops Classic" {
+		t.Errorf("tournament = %q", games[0].Tournament)
+	}
+
+	// "Lightning 12U Black" matches no team, which likely means a missing alias
+	if len(BuildWarnings) != 1 || !strings.Contains(BuildWarnings[0], "Lightning 12U Black") {
+		t.Errorf("warnings = %q", BuildWarnings)
+	}
+}
+
+func TestScrapeExposureTable(t *testing.T) {
+	setupTestClub(t)
+	srv := fixtureServer(t)
+	team := &AllTeams[0]
+
+	games, err := scrapeExposureSchedule(team, srv.URL+"/basketball.exposureevents.com/301122/winter-shootout?fixture=exposure-table.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkGames(t, games, []wantGame{
+		{"Saturday, December 6, 2025", "8:00 AM", "Des Moines Heat", "Away", "L 30-35", "Ralston Arena", "Court 4", "ee-301122-101"},
+		{"Saturday, December 6, 2025", "1:15 PM", "Sioux City Storm", "Home", "", "", "Court 1", "ee-301122-115"},
+	})
+	if games[0].Tournament != "Winter Shootout" || games[0].Division != "12U Girls" {
+		t.Errorf("tournament = %q, division = %q", games[0].Tournament, games[0].Division)
+	}
+}
+
+func TestScrapeExposureWithoutTeamGames(t *testing.T) {
+	setupTestClub(t)
+	srv := fixtureServer(t)
+	team := &Team{Name: "14U Red", CBLName: "Lightning 14U Red"}
+
+	_, err := scrapeExposureSchedule(team, srv.URL+"/basketball.exposureevents.com/301122/winter-shootout?fixture=exposure-table.html")
+	if err == nil || !strings.Contains(err.Error(), "none for the team") {
+		t.Errorf("err = %v, want a no games for the team error", err)
+	}
+}
+
+func TestParseExposureTime(t *testing.T) {
+	tests := []struct {
+		text    string
+		want    time.Time
+		hasTime bool
+		ok      bool
+	}{
+		{"2025-10-18T09:00:00-05:00", time.Date(2025, 10, 18, 9, 0, 0, 0, time.UTC), true, true},
+		{"2025-10-18T14:30:00", time.Date(2025, 10, 18, 14, 30, 0, 0, time.UTC), true, true},
+		{"2025-10-18", time.Date(2025, 10, 18, 0, 0, 0, 0, time.UTC), false, true},
+		{"Sat, Oct 18, 2025  9:00 AM", time.Date(2025, 10, 18, 9, 0, 0, 0, time.UTC), true, true},
+		{"Saturday, October 18, 2025 1:15 PM", time.Date(2025, 10, 18, 13, 15, 0, 0, time.UTC), true, true},
+		{"10/18/2025", time.Date(2025, 10, 18, 0, 0, 0, 0, time.UTC), false, true},
+		{"10/18/25 6:00 PM", time.Date(2025, 10, 18, 18, 0, 0, 0, time.UTC), true, true},
+		{"TBD", time.Time{}, false, false},
+	}
+	for _, test := range tests {
+		got, hasTime, ok := parseExposureTime(test.text)
+		if !got.Equal(test.want) || hasTime != test.hasTime || ok != test.ok {
+			t.Errorf("parseExposureTime(%q) = %v, %v, %v; want %v, %v, %v", test.text, got, hasTime, ok, test.want, test.hasTime, test.ok)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Filter selects the games and notes that make up a view of the schedule
+// (a page, a calendar, or both). The zero value matches everything.
+type Filter struct {
+	Title    string    // Page/calendar title (e.g., "10U Blue"); "" for the club-wide view
+	Path     string    // URL path of the view (e.g., "/10u-blue/")
+	Teams    []*Team   // Games for any of these teams; empty means all teams
+	Location *Location // Games at this venue
+	From     time.Time // First date included; zero means unbounded
+	To       time.Time // Last date included; zero means unbounded
+	HomeAway string    // "Home", "Away" or "" for both
+	Played   *bool     // Only played (true) or upcoming (false) games; nil for both
+	Opponent string    // Case-insensitive substring of the opponent's name
+
+	GroupByLocation bool // Show each day's games under a header per venue
+	FlagConflicts   bool // Warn about games of different teams that overlap
+}
+
+// AllGamesFilter is the club-wide schedule at the site root
+func AllGamesFilter() Filter {
+	return Filter{Path: "/"}
+}
+
+// TeamFilter is a single team's schedule
+func TeamFilter(team *Team) Filter {
+	return Filter{Title: team.Name, Path: "/" + team.Slug + "/", Teams: []*Team{team}}
+}
+
+// LocationFilter is every game at a single venue
+func LocationFilter(location *Location) Filter {
+	return Filter{Title: location.Name, Path: "/locations/" + location.Slug() + "/", Location: location}
+}
+
+// IsAll reports whether the filter is the unrestricted club-wide view
+func (f Filter) IsAll() bool {
+	return len(f.Teams) == 0 && f.Location == nil && f.From.IsZero() && f.To.IsZero() &&
+		f.HomeAway == "" && f.Played == nil && f.Opponent == ""
+}
+
+// HasTeam reports whether team is one of the filter's teams
+func (f Filter) HasTeam(team *Team) bool {
+	for _, t := range f.Teams {
+		if t.Slug == team.Slug {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchGame reports whether the game belongs in the view
+func (f Filter) MatchGame(game *Game) bool {
+	if len(f.Teams) > 0 && !f.HasTeam(game.Team) {
+		return false
+	}
+	if f.Location != nil && game.Location != f.Location {
+		return false
+	}
+	if f.HomeAway != "" && !strings.EqualFold(game.HomeAway, f.HomeAway) {
+		return false
+	}
+	if f.Opponent != "" && !strings.Contains(strings.ToLower(game.Opponent), strings.ToLower(f.Opponent)) {
+		return false
+	}
+
+	date := parseDateForSorting(game.Date)
+	if !f.inRange(date, date) {
+		return false
+	}
+
+	if f.Played != nil && game.IsPastGame(date, time.Now().UTC()) != *f.Played {
+		return false
+	}
+	return true
+}
+
+// MatchNote reports whether the note belongs in the view. Notes follow the
+// teams in the view; views narrowed by anything other than team or date
+// (a venue, away games, ...) span teams, so only club-wide notes apply.
+func (f Filter) MatchNote(note *Note) bool {
+	start := parseDateForSorting(note.Date)
+	end := start
+	if note.EndDate != "" {
+		end = parseDateForSorting(note.EndDate)
+	}
+	if !f.inRange(start, end) {
+		return false
+	}
+
+	teamsLower := strings.ToLower(note.Teams)
+	if teamsLower == "all teams" {
+		return true
+	}
+	if f.Location != nil || f.HomeAway != "" || f.Played != nil || f.Opponent != "" {
+		return false
+	}
+	if len(f.Teams) == 0 {
+		return true
+	}
+
+	// For team views, only show notes that name one of the teams
+	for _, team := range f.Teams {
+		if strings.Contains(teamsLower, strings.ToLower(team.Name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Games returns the games matching the filter, in their original order
+func (f Filter) Games(games []Game) []Game {
+	var matched []Game
+	for i := range games {
+		if f.MatchGame(&games[i]) {
+			matched = append(matched, games[i])
+		}
+	}
+	return matched
+}
+
+// Notes returns the notes matching the filter, in their original order.
+// The same note entered once per team is only included once.
+func (f Filter) Notes(notes []Note) []Note {
+	var matched []Note
+	seen := make(map[string]bool)
+	for i := range notes {
+		if !f.MatchNote(&notes[i]) {
+			continue
+		}
+		key := notes[i].Date + "|" + notes[i].EndDate + "|" + notes[i].Text
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		matched = append(matched, notes[i])
+	}
+	return matched
+}
+
+// inRange reports whether [start, end] overlaps the filter's date range.
+// Undated items (year 2099) only match views without a range.
+func (f Filter) inRange(start, end time.Time) bool {
+	if f.From.IsZero() && f.To.IsZero() {
+		return true
+	}
+	if start.Year() == 2099 {
+		return false
+	}
+	if !f.From.IsZero() && end.Before(dateOnly(f.From)) {
+		return false
+	}
+	if !f.To.IsZero() && start.After(dateOnly(f.To)) {
+		return false
+	}
+	return true
+}
+
+// dateOnly drops the time of day, matching how parseDateForSorting parses dates
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// fetchViews reads extra schedule views from the Views tab. Each row becomes
+// a page and calendar at /<Slug>/ built from its filter columns, e.g.
+// "all away games" (Home/Away = Away) or "10U tournament" (Teams + From/To).
+func fetchViews() ([]Filter, error) {
+	headers, records, err := fetchSheetTab("Views")
+	if err != nil {
+		return nil, err
+	}
+
+	var views []Filter
+	for _, record := range records {
+		slug := getCellValue(headers, record, "Slug")
+		title := getCellValue(headers, record, "Title")
+
+		// Skip rows with missing data
+		if slug == "" || title == "" {
+			continue
+		}
+
+		view, err := parseViewRow(headers, record)
+		if err != nil {
+			addBuildWarning("skipping view %s: %v", slug, err)
+			continue
+		}
+		view.Title = title
+		view.Path = "/" + slugify(slug) + "/"
+		views = append(views, view)
+	}
+
+	return views, nil
+}
+
+// parseViewRow turns the filter columns of a Views row into a Filter
+func parseViewRow(headers, record []string) (Filter, error) {
+	var view Filter
+
+	for _, name := range strings.Split(getCellValue(headers, record, "Teams"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || strings.EqualFold(name, "All Teams") {
+			continue
+		}
+		team := findTeamByName(name)
+		if team == nil {
+			return view, fmt.Errorf("unknown team %q", name)
+		}
+		view.Teams = append(view.Teams, team)
+	}
+
+	if abbrev := getCellValue(headers, record, "Location"); abbrev != "" {
+		loc, _ := findLocationByAbbrev(abbrev)
+		if loc == nil {
+			return view, fmt.Errorf("unknown location %q", abbrev)
+		}
+		view.Location = loc
+	}
+
+	for _, bound := range []struct {
+		column string
+		value  *time.Time
+	}{{"From", &view.From}, {"To", &view.To}} {
+		text := getCellValue(headers, record, bound.column)
+		if text == "" {
+			continue
+		}
+		date := parseDateForSorting(text)
+		if date.Year() == 2099 {
+			return view, fmt.Errorf("invalid %s date %q", bound.column, text)
+		}
+		*bound.value = date
+	}
+
+	switch homeAway := strings.ToLower(getCellValue(headers, record, "Home/Away")); homeAway {
+	case "":
+	case "home", "away":
+		view.HomeAway = strings.ToUpper(homeAway[:1]) + homeAway[1:]
+	default:
+		return view, fmt.Errorf("invalid Home/Away %q", homeAway)
+	}
+
+	switch status := strings.ToLower(getCellValue(headers, record, "Status")); status {
+	case "":
+	case "played":
+		played := true
+		view.Played = &played
+	case "upcoming", "unplayed":
+		played := false
+		view.Played = &played
+	default:
+		return view, fmt.Errorf("invalid Status %q", status)
+	}
+
+	view.Opponent = getCellValue(headers, record, "Opponent")
+	view.GroupByLocation = strings.EqualFold(getCellValue(headers, record, "Group"), "Location")
+	return view, nil
+}
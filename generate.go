@@ -1,40 +1,103 @@
 package main
 
 import (
+	"crypto/sha1"
 	_ "embed"
-	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"html/template"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	_ "time/tzdata" // Embedded so VTIMEZONEs don't depend on the host's zoneinfo
+	"unicode"
 
 	"github.com/PuerkitoBio/goquery"
+
+	"lightning/schedule/internal/ical"
 )
 
 // Constants
 const domain = "schedule.omahalightningbasketball.com"
+const homeTimezone = "America/Chicago" // Where game times are played unless noted otherwise
 const googleSheetID = "1JG0KliyzTT8muoDPAhTJWBilE1iUQMm22XOq1H4N6aQ"
 const googleSheetCSVURL = "https://docs.google.com/spreadsheets/d/" + googleSheetID + "/export?format=csv"
 const googleSheetNotesCSVURL = "https://docs.google.com/spreadsheets/d/" + googleSheetID + "/export?format=csv&gid=436458989"
 const googleSheetLocationsCSVURL = "https://docs.google.com/spreadsheets/d/" + googleSheetID + "/export?format=csv&gid=1311642203"
 const googleSheetTeamsCSVURL = "https://docs.google.com/spreadsheets/d/" + googleSheetID + "/export?format=csv&gid=440511811"
 
+// Tab names for the tabs the public export reads by gid
+const gamesTab = "Games"
+const notesTab = "Notes"
+const locationsTab = "Locations"
+const teamsTab = "Teams"
+
+// Newer tabs are read from an export of the whole workbook, by name
+const googleSheetXLSXURL = "https://docs.google.com/spreadsheets/d/" + googleSheetID + "/export?format=xlsx"
+
+// Suffix of the file that remembers iCal event revisions between runs. It
+// sits next to the output directory (e.g. ".dist-event-revisions.json"), not
+// inside it, so the web server never publishes it.
+const eventRevisionsFile = "-event-revisions.json"
+
 // Variables
-//
+
+// Map links point at "google", "apple" or "osm" (OpenStreetMap); set with -map-provider
+var mapProvider = "google"
+
+// Which source's copy of a game listed by several sources wins, first to
+// last; set with -merge-precedence
+var mergePrecedence = "sheet,tourneymachine,exposure,ical"
+
+// Seasons run a year from this month; other than January they're named e.g.
+// "2025-26". Set with -season-start-month.
+var seasonStartMonth = time.January
+
+// Season shown on the main pages; "" means the season containing today. Set
+// with -season.
+var currentSeason = ""
+
 // Supports markdown-style links: [text](url) -> <a href="url">text</a>
 // Shared regex for matching markdown links [text](url)
 var markdownLinkRegex = regexp.MustCompile(`\[([^\]]+)\]\(([^\)]+)\)`)
 
+// Matches TourneyMachine bracket placeholders such as "Winner of Game 12" or "Loser #7"
+var bracketPlaceholderRegex = regexp.MustCompile(`(?i)^(winner|loser)\s+(?:of\s+)?(?:game\s+)?#?\s*(\d+)$`)
+
+// Matches schedule date headers like "Saturday, October 18, 2025"
+var scheduleDateRegex = regexp.MustCompile(`\w+day,\s+\w+\s+\d+,\s+\d{4}`)
+
+// Matches a date prefix on a game time like "Sat 10/18/25 6:00 PM"
+var scheduleTimeDateRegex = regexp.MustCompile(`^(?:(?:Mon|Tue|Wed|Thu|Fri|Sat|Sun)\w*\s+)?(\d+/\d+/\d+)\s+`)
+
+// Matches TourneyMachine schedule headers naming a pool or bracket round
+var tournamentRoundRegex = regexp.MustCompile(`(?i)\b(pool|bracket|round|quarterfinals?|semifinals?|finals?|championship|consolation|playoffs?)\b`)
+
 //go:embed templates/schedule.html
 var scheduleTemplate string
 
+//go:embed templates/locations.html
+var locationsTemplate string
+
+//go:embed templates/standings.html
+var standingsTemplate string
+
+//go:embed templates/opponents.html
+var opponentsTemplate string
+
+//go:embed templates/archive.html
+var archiveTemplate string
+
 //go:embed templates/schedule.css
 var stylesCSS string
 
@@ -43,24 +106,51 @@ var scheduleJS string
 
 var AllLocations []Location
 var AllTeams []Team
+var HomeLocation *time.Location
+var EventRevisions = map[string]*EventRevision{}
+
+// Guards EventRevisions, which server mode reads from concurrent requests
+var eventRevisionsMu sync.Mutex
+
+// Once the build has saved its revisions, feeds served on demand are stamped
+// as of this time without changing (or saving) EventRevisions
+var eventRevisionsFrozenAt time.Time
+
+// Problems that didn't stop the build but need a look, repeated at the end of the run
+var BuildWarnings []string
+
+// addBuildWarning prints a warning now and keeps it for the build summary
+func addBuildWarning(format string, args ...any) {
+	warning := fmt.Sprintf(format, args...)
+	fmt.Printf("Warning: %s\n", warning)
+	BuildWarnings = append(BuildWarnings, warning)
+}
 
 // Types
 
 // Location represents a game location
 type Location struct {
-	Abbrev  string
-	Name    string
-	Address string
+	Abbrev    string
+	Name      string
+	Address   string
+	Timezone  *time.Location // nil means the home timezone
+	Latitude  float64
+	Longitude float64
+	HasGeo    bool   // Latitude/Longitude were provided
+	Parking   string // Parking notes (e.g., "Use the north lot")
+	GymMap    string // Link to an entrance/gym map
 }
 
 type Team struct {
-	Name     string
-	Slug     string
-	CssClass string
-	Order    int
-	CBLLink1 string
-	CBLLink2 string
-	CBLName  string
+	Name        string
+	Slug        string
+	CssClass    string
+	Order       int
+	Sources     []Source // Schedule pages, from the Teams link columns and the TeamSources tab
+	CBLName     string
+	CBLAliases  []string         // Other names tournaments list the team under
+	CBLPatterns []*regexp.Regexp // Case-insensitive patterns for names that vary by event
+	Jerseys     []Jersey         // Jersey sets, from the Jerseys tab
 }
 
 // Game represents a single game
@@ -72,12 +162,22 @@ type Game struct {
 	CourtGymInfo string // Court/Gym information (e.g., "court 1", "gym a")
 	Opponent     string
 	HomeAway     string
+	Jersey       string // Name of the team's jersey set, when the sheet names one (e.g., "Gold")
 	Score        string
-	Result       string // "W", "L", or "" for unplayed games
+	Result       string   // "W", "L", or "" for unplayed games
+	Season       string   // e.g. "2025"; from the sheet's Season column or the game date
+	Tournament   string   // Tournament the game is part of, if any (e.g., "Fall Classic")
+	Division     string   // Tournament division (e.g., "10U Girls")
+	Round        string   // Pool or bracket round (e.g., "Pool A", "Semifinal")
+	Condition    string   // For possible bracket games, what has to happen first (e.g., "If 10U Blue wins Game 12")
+	Warnings     []string // Conflicts with other games in a coach/family group
+	SourceID     string   // Stable identity from the source (e.g., "tm-<tournament>-<game #>", "sheet-<ID>")
+	Origin       string   // Where the game came from: "sheet" or a source provider (e.g., "tourneymachine")
 }
 
 // Note represents a note to display on a specific date
 type Note struct {
+	ID       string // Optional stable identity from the sheet's ID column
 	Date     string
 	Text     string
 	HTMLText template.HTML // HTML-safe version of Text for template rendering
@@ -85,6 +185,46 @@ type Note struct {
 	EndDate  string        // For multi-day notes, the last date (used for "past" determination)
 }
 
+// tourneyMachineRow is one game row of a TourneyMachine schedule table
+type tourneyMachineRow struct {
+	GameNum      string
+	Date         string
+	Time         string
+	Location     string
+	Visitor      string
+	VisitorScore string
+	HomeScore    string
+	Home         string
+	Round        string
+}
+
+// scheduleColumns are the positions of a TourneyMachine schedule table's
+// columns; -1 means the table doesn't have the column
+type scheduleColumns struct {
+	Game         int
+	Time         int
+	Location     int
+	Visitor      int
+	VisitorScore int
+	HomeScore    int
+	Home         int
+}
+
+var defaultScheduleColumns = scheduleColumns{Game: 0, Time: 1, Location: 2, Visitor: 3, VisitorScore: 4, HomeScore: 5, Home: 6}
+
+// maxIndex returns the last column a data row must have
+func (c scheduleColumns) maxIndex() int {
+	return max(c.Game, c.Time, c.Location, c.Visitor, c.VisitorScore, c.HomeScore, c.Home)
+}
+
+// EventRevision tracks changes to a single iCal event across runs so calendar
+// apps see an update (SEQUENCE/LAST-MODIFIED) instead of a delete and re-add
+type EventRevision struct {
+	Hash         string    `json:"hash"`
+	Sequence     int       `json:"sequence"`
+	LastModified time.Time `json:"lastModified"`
+}
+
 // ScheduleItem represents either a game or a note in the schedule
 type ScheduleItem struct {
 	IsNote bool
@@ -100,6 +240,10 @@ type TeamButton struct {
 
 type TemplateScheduleItem struct {
 	IsNote          bool
+	IsGroupHeader   bool
+	GroupHTML       template.HTML
+	Tournament      string // Key of the collapsible tournament group the row belongs to
+	Warning         string // Conflict with another team's game, if any
 	IsWeekStart     bool
 	IsPastGame      bool
 	IsPastNote      bool
@@ -107,20 +251,39 @@ type TemplateScheduleItem struct {
 	Note            *Note
 	DisplayDateTime string
 	LocationHTML    template.HTML
-	JerseyText      string
+	JerseyHTML      template.HTML
 	OpponentDisplay string
+	OpponentSlug    string // Link to the opponent's page; empty for TBD
 	ScoreDisplay    string
 }
 
+// LocationSummary is a row on the locations index page
+type LocationSummary struct {
+	Location      *Location
+	Slug          string
+	AddressHTML   template.HTML
+	UpcomingGames int
+}
+
+type LocationsTemplateData struct {
+	PageTitle      string
+	UpdatedDisplay string
+	Teams          []TeamButton
+	Locations      []LocationSummary
+	StylesCSS      template.CSS
+}
+
 type TemplateData struct {
 	ProdDomain     string
 	PageTitle      string
 	PagePath       string
 	UpdatedUTC     string
 	UpdatedDisplay string
+	TimeZone       string
 	AllTeamsLink   string
 	IsAllTeams     bool
 	TeamRecord     string
+	Stats          *TeamStats // Set on single-team pages
 	Teams          []TeamButton
 	ScheduleItems  []TemplateScheduleItem
 	StylesCSS      template.CSS
@@ -129,45 +292,57 @@ type TemplateData struct {
 
 // Functions
 func fetchLocations() ([]Location, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(googleSheetLocationsCSVURL)
+	headers, records, err := fetchSheetTab(locationsTab)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching locations sheet: %v", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	reader := csv.NewReader(resp.Body)
 	var AllLocations []Location
 
-	// Read header row
-	headers, err := reader.Read()
-	if err != nil {
-		return nil, fmt.Errorf("error reading CSV header: %v", err)
-	}
-
 	// Parse data rows
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			continue
-		}
-
+	for _, record := range records {
 		abbreviation := getCellValue(headers, record, "Abbrev")
 		name := getCellValue(headers, record, "Name")
 		address := getCellValue(headers, record, "Address")
+		timezone := getCellValue(headers, record, "Timezone")
+		latitude := getCellValue(headers, record, "Latitude")
+		longitude := getCellValue(headers, record, "Longitude")
+		parking := getCellValue(headers, record, "Parking")
+		gymMap := getCellValue(headers, record, "Gym Map")
 
 		// Skip rows with missing data
 		if name == "" {
 			continue
 		}
 
+		// Coordinates are optional but must come as a valid pair
+		lat, latErr := strconv.ParseFloat(latitude, 64)
+		lon, lonErr := strconv.ParseFloat(longitude, 64)
+		hasGeo := latErr == nil && lonErr == nil
+		if !hasGeo && (latitude != "" || longitude != "") {
+			addBuildWarning("ignoring invalid coordinates %q, %q for %s", latitude, longitude, name)
+		}
+
+		// Out-of-town venues may play in another timezone (e.g., "America/Denver")
+		var tz *time.Location
+		if timezone != "" {
+			tz, err = time.LoadLocation(timezone)
+			if err != nil {
+				addBuildWarning("unknown timezone %q for %s: %v", timezone, name, err)
+				tz = nil
+			}
+		}
+
 		AllLocations = append(AllLocations, Location{
-			Abbrev:  abbreviation,
-			Name:    name,
-			Address: address,
+			Abbrev:    abbreviation,
+			Name:      name,
+			Address:   address,
+			Timezone:  tz,
+			Latitude:  lat,
+			Longitude: lon,
+			HasGeo:    hasGeo,
+			Parking:   parking,
+			GymMap:    gymMap,
 		})
 	}
 
@@ -175,36 +350,17 @@ func fetchLocations() ([]Location, error) {
 }
 
 func fetchTeams() ([]Team, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(googleSheetTeamsCSVURL)
+	headers, records, err := fetchSheetTab(teamsTab)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching teams sheet: %v", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	reader := csv.NewReader(resp.Body)
 	var teams []Team
 
-	// Read header row
-	headers, err := reader.Read()
-	if err != nil {
-		return nil, fmt.Errorf("error reading CSV header: %v", err)
-	}
-
 	// Parse data rows
 	order := 1
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			continue
-		}
-
+	for _, record := range records {
 		name := getCellValue(headers, record, "Name")
-		cblLink1 := getCellValue(headers, record, "CBLLink1")
-		cblLink2 := getCellValue(headers, record, "CBLLink2")
 		cblName := getCellValue(headers, record, "CBLName")
 		slug := getCellValue(headers, record, "Slug")
 		css := getCellValue(headers, record, "CSS")
@@ -214,14 +370,40 @@ func fetchTeams() ([]Team, error) {
 			continue
 		}
 
+		var aliases []string
+		for _, alias := range strings.Split(getCellValue(headers, record, "CBLAliases"), ";") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				aliases = append(aliases, alias)
+			}
+		}
+		patterns, err := parseTeamPatterns(getCellValue(headers, record, "CBLPatterns"))
+		if err != nil {
+			addBuildWarning("%s: %v", name, err)
+		}
+
+		// Links come from the original two columns and CBLLinks (separated by
+		// semicolons or whitespace); the TeamSources tab adds more with metadata
+		var urls []string
+		for _, column := range []string{"CBLLink1", "CBLLink2"} {
+			if url := getCellValue(headers, record, column); url != "" {
+				urls = append(urls, url)
+			}
+		}
+		urls = append(urls, strings.Fields(strings.ReplaceAll(getCellValue(headers, record, "CBLLinks"), ";", " "))...)
+		var sources []Source
+		for _, url := range urls {
+			sources = append(sources, Source{URL: url, Provider: detectProvider(url)})
+		}
+
 		teams = append(teams, Team{
-			Name:     name,
-			Slug:     slug,
-			CssClass: css,
-			Order:    order,
-			CBLLink1: cblLink1,
-			CBLLink2: cblLink2,
-			CBLName:  cblName,
+			Name:        name,
+			Slug:        slug,
+			CssClass:    css,
+			Order:       order,
+			Sources:     sources,
+			CBLName:     cblName,
+			CBLAliases:  aliases,
+			CBLPatterns: patterns,
 		})
 		order++
 	}
@@ -251,6 +433,38 @@ func findLocationByName(name string) (*Location, string) {
 	return nil, courtGymInfo
 }
 
+// findLocationByAddress finds the venue whose street address appears in the
+// text, ignoring case, punctuation and common abbreviations ("Street"/"St")
+func findLocationByAddress(text string) *Location {
+	normalized := " " + normalizeAddress(text) + " "
+	for i := range AllLocations {
+		street := normalizeAddress(strings.Split(AllLocations[i].Address, ",")[0])
+		if street != "" && strings.Contains(normalized, " "+street+" ") {
+			return &AllLocations[i]
+		}
+	}
+	return nil
+}
+
+// Common street words and the abbreviations we compare addresses with
+var addressAbbreviations = map[string]string{
+	"street": "st", "avenue": "ave", "road": "rd", "drive": "dr", "boulevard": "blvd",
+	"lane": "ln", "court": "ct", "place": "pl", "parkway": "pkwy", "highway": "hwy",
+	"north": "n", "south": "s", "east": "e", "west": "w",
+}
+
+func normalizeAddress(address string) string {
+	fields := strings.FieldsFunc(strings.ToLower(address), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, field := range fields {
+		if abbrev, ok := addressAbbreviations[field]; ok {
+			fields[i] = abbrev
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
 func findLocationByAbbrev(abbrev string) (*Location, string) {
 	abbrev = strings.TrimSpace(abbrev)
 	if abbrev == "" || abbrev == "TBD" {
@@ -273,6 +487,79 @@ func findLocationByAbbrev(abbrev string) (*Location, string) {
 	return nil, courtGymInfo
 }
 
+// MapURL returns a link to the location in the configured map provider,
+// preferring coordinates over the address. Empty if neither is known.
+func (l *Location) MapURL() string {
+	if !l.HasGeo && l.Address == "" {
+		return ""
+	}
+
+	coords := fmt.Sprintf("%g,%g", l.Latitude, l.Longitude)
+	query := url.Values{}
+
+	switch mapProvider {
+	case "apple":
+		if l.HasGeo {
+			query.Set("ll", coords)
+			query.Set("q", l.Name)
+		} else {
+			query.Set("address", l.Address)
+		}
+		return "https://maps.apple.com/?" + query.Encode()
+	case "osm":
+		if l.HasGeo {
+			query.Set("mlat", fmt.Sprintf("%g", l.Latitude))
+			query.Set("mlon", fmt.Sprintf("%g", l.Longitude))
+			return fmt.Sprintf("https://www.openstreetmap.org/?%s#map=17/%g/%g", query.Encode(), l.Latitude, l.Longitude)
+		}
+		query.Set("query", l.Address)
+		return "https://www.openstreetmap.org/search?" + query.Encode()
+	default:
+		query.Set("api", "1")
+		if l.HasGeo {
+			query.Set("query", coords)
+		} else {
+			query.Set("query", l.Address)
+		}
+		return "https://www.google.com/maps/search/?" + query.Encode()
+	}
+}
+
+// Slug returns the URL path segment for the location's page
+func (l *Location) Slug() string {
+	if l.Abbrev != "" {
+		return slugify(l.Abbrev)
+	}
+	return slugify(l.Name)
+}
+
+// FullAddress returns "Name, Address" for calendar LOCATION fields
+func (l *Location) FullAddress() string {
+	if l.Address == "" {
+		return l.Name
+	}
+	return l.Name + ", " + l.Address
+}
+
+// locationHTML renders a location's abbreviation linked to its map, followed
+// by a gym map link and parking notes (as a tooltip) when available
+func locationHTML(l *Location, label string) string {
+	html := template.HTMLEscapeString(label)
+	if mapURL := l.MapURL(); mapURL != "" {
+		html = fmt.Sprintf(`<a href="%s" target="_blank">%s</a>`,
+			template.HTMLEscapeString(mapURL), html)
+	}
+	if l.GymMap != "" {
+		html += fmt.Sprintf(` <a href="%s" target="_blank" class="gym-map" title="Gym map">🗺️</a>`,
+			template.HTMLEscapeString(l.GymMap))
+	}
+	if l.Parking != "" {
+		html += fmt.Sprintf(` <span class="parking" title="%s">🅿️</span>`,
+			template.HTMLEscapeString(l.Parking))
+	}
+	return html
+}
+
 func findTeamByName(teamName string) *Team {
 	for i := range AllTeams {
 		if AllTeams[i].Name == teamName {
@@ -283,32 +570,15 @@ func findTeamByName(teamName string) *Team {
 }
 
 func fetchGoogleSheetGames() ([]Game, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(googleSheetCSVURL)
+	headers, records, err := fetchSheetTab(gamesTab)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching Google Sheet: %v", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	reader := csv.NewReader(resp.Body)
 	var games []Game
 
-	// Read header row
-	headers, err := reader.Read()
-	if err != nil {
-		return nil, fmt.Errorf("error reading CSV header: %v", err)
-	}
-
 	// Parse data rows
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			continue
-		}
-
+	for _, record := range records {
 		team := findTeamByName(getCellValue(headers, record, "Team"))
 		date := getCellValue(headers, record, "Date")
 		timeStr := getCellValue(headers, record, "Time")
@@ -316,23 +586,20 @@ func fetchGoogleSheetGames() ([]Game, error) {
 		jersey := getCellValue(headers, record, "Jersey")
 		opponent := getCellValue(headers, record, "Opponent")
 		score := getCellValue(headers, record, "Score")
+		id := getCellValue(headers, record, "ID")
+		season := getCellValue(headers, record, "Season")
+		tournament := getCellValue(headers, record, "Tournament")
+		division := getCellValue(headers, record, "Division")
+		round := getCellValue(headers, record, "Round")
 
 		// Skip rows with missing critical data
 		if team == nil || date == "" || opponent == "" {
 			continue
 		}
 
-		// Determine home/away from jersey field
-		homeAway := ""
-		jerseyLower := strings.ToLower(jersey)
-		switch {
-		case strings.Contains(jerseyLower, "home"),
-			strings.Contains(jerseyLower, "light"):
-			homeAway = "Home"
-		case strings.Contains(jerseyLower, "away"),
-			strings.Contains(jerseyLower, "visitor"),
-			strings.Contains(jerseyLower, "dark"):
-			homeAway = "Away"
+		sourceID := ""
+		if id != "" {
+			sourceID = "sheet-" + slugify(id)
 		}
 
 		// Parse date to standard format
@@ -374,33 +641,46 @@ func fetchGoogleSheetGames() ([]Game, error) {
 		// Find location by abbreviation (Google Sheets uses abbreviations)
 		loc, courtGymInfo := findLocationByAbbrev(location)
 
-		games = append(games, Game{
+		game := Game{
 			Team:         team,
 			Date:         formattedDate,
 			Time:         timeStr,
 			Location:     loc,
 			CourtGymInfo: courtGymInfo,
 			Opponent:     opponent,
-			HomeAway:     homeAway,
 			Score:        score,
 			Result:       result,
-		})
+			Season:       season,
+			Tournament:   tournament,
+			Division:     division,
+			Round:        round,
+			SourceID:     sourceID,
+			Origin:       "sheet",
+		}
+
+		// Determine the jersey set and home/away from jersey field
+		applyJersey(&game, jersey)
+
+		games = append(games, game)
 	}
 
 	return games, nil
 }
 
-func isPresent(v any) bool {
-	if v == nil {
-		return false
-	}
-	if s, ok := v.(*string); ok {
-		return s != nil && *s != ""
+// homeAwayFromJersey reads home/away from a Jersey cell ("Home", "Light",
+// "Away", "Visitor" or "Dark"); anything else is unknown
+func homeAwayFromJersey(jersey string) string {
+	jerseyLower := strings.ToLower(jersey)
+	switch {
+	case strings.Contains(jerseyLower, "home"),
+		strings.Contains(jerseyLower, "light"):
+		return "Home"
+	case strings.Contains(jerseyLower, "away"),
+		strings.Contains(jerseyLower, "visitor"),
+		strings.Contains(jerseyLower, "dark"):
+		return "Away"
 	}
-	if s, ok := v.(string); ok {
-		return s != ""
-	}
-	return false
+	return ""
 }
 
 // getCellValue retrieves a cell value from a record by header name
@@ -417,6 +697,19 @@ func getCellValue(headers []string, record []string, headerName string) string {
 	return ""
 }
 
+// fetchSheetTab reads a tab by name from the sheet backend and returns its
+// header row and data rows
+func fetchSheetTab(tab string) ([]string, [][]string, error) {
+	rows, err := Sheet.ReadTab(tab)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("%s sheet has no header row", tab)
+	}
+	return rows[0], rows[1:], nil
+}
+
 func parseNoteTextWithLinks(text string) string {
 	// Convert markdown links to HTML anchor tags
 	text = markdownLinkRegex.ReplaceAllString(text, `<a href="$2" target="_blank">$1</a>`)
@@ -424,32 +717,16 @@ func parseNoteTextWithLinks(text string) string {
 }
 
 func fetchGoogleSheetNotes() ([]Note, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(googleSheetNotesCSVURL)
+	headers, records, err := fetchSheetTab(notesTab)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching Google Sheet notes: %v", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	reader := csv.NewReader(resp.Body)
 	var notes []Note
 
-	// Read header row
-	headers, err := reader.Read()
-	if err != nil {
-		return nil, fmt.Errorf("error reading CSV header: %v", err)
-	}
-
 	// Parse data rows
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			continue
-		}
-
+	for _, record := range records {
+		id := getCellValue(headers, record, "ID")
 		date := getCellValue(headers, record, "Date")
 		endDate := getCellValue(headers, record, "End Date")
 		text := getCellValue(headers, record, "Text")
@@ -486,6 +763,7 @@ func fetchGoogleSheetNotes() ([]Note, error) {
 		htmlText := parseNoteTextWithLinks(text)
 
 		notes = append(notes, Note{
+			ID:       id,
 			Date:     formattedDate,
 			EndDate:  formattedEndDate,
 			Text:     text,                    // Store raw text
@@ -497,187 +775,431 @@ func fetchGoogleSheetNotes() ([]Note, error) {
 	return notes, nil
 }
 
-func scrapeTeamSchedule(displayName, url, htmlName, CssClass string) ([]Game, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	// Create request with browser-like headers to avoid Cloudflare blocking
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %v", err)
-	}
-
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
-	req.Header.Set("Connection", "keep-alive")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error fetching %s: %v", displayName, err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("received status code %d for %s", resp.StatusCode, displayName)
-	}
-
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
+func scrapeTeamSchedule(team *Team, url string) ([]Game, error) {
+	doc, err := fetchSchedulePage(team, url)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing HTML: %v", err)
+		return nil, err
 	}
 
-	var games []Game
+	var rows []tourneyMachineRow
 	currentDate := ""
+	currentRound := ""
+	tournamentID := tourneyMachineTournamentID(url)
+	tournament, division := tourneyMachineHeadings(doc)
 
 	// Find all tables and look for schedule data
 	doc.Find("table").Each(func(_ int, table *goquery.Selection) {
+		// Until a header row says otherwise, assume the classic layout:
+		// Game, Time, Location, Visitor, Visitor Score, Home Score, Home, (blank)
+		columns := defaultScheduleColumns
+
 		table.Find("tr").Each(func(_ int, row *goquery.Selection) {
-			// Check if this is a header row with a date
+			// A row naming the columns tells us where everything is
+			if found, ok := scheduleColumnsFromHeader(row); ok {
+				columns = found
+				return
+			}
+
+			// Check if this is a header row with a date or round
 			thCells := row.Find("th")
 			if thCells.Length() > 0 {
 				headerText := strings.TrimSpace(row.Text())
 				// Look for date pattern like "Saturday, October 18, 2025"
-				if matched, _ := regexp.MatchString(`\w+day,\s+\w+\s+\d+,\s+\d{4}`, headerText); matched {
-					currentDate = headerText
+				if match := scheduleDateRegex.FindString(headerText); match != "" {
+					currentDate = match
+				} else if thCells.Length() == 1 && tournamentRoundRegex.MatchString(headerText) {
+					// Pool play and bracket games are listed under headers like "Pool A" or "Gold Bracket - Semifinals"
+					currentRound = strings.Join(strings.Fields(headerText), " ")
 				}
 			}
 
-			// Look for table data rows
+			// Look for table data rows with every column we need (extra columns are fine)
 			cells := row.Find("td")
-
-			// The schedule table has 8 columns: Game, Time, Location, Visitor, Visitor Score, Home Score, Home, (blank)
-			if cells.Length() == 8 && currentDate != "" {
-				gameNum := strings.TrimSpace(cells.Eq(0).Text())
-				timeStr := strings.TrimSpace(cells.Eq(1).Text())
-				location := strings.TrimSpace(cells.Eq(2).Text())
-				visitor := strings.TrimSpace(cells.Eq(3).Text())
-				visitorScore := strings.TrimSpace(cells.Eq(4).Text())
-				homeScore := strings.TrimSpace(cells.Eq(5).Text())
-				home := strings.TrimSpace(cells.Eq(6).Text())
-
-				// Remove date prefix from time if present (e.g., "Sat 10/18/25 6:00 PM" -> "6:00 PM")
-				re := regexp.MustCompile(`^(Mon|Tue|Wed|Thu|Fri|Sat|Sun)\s+\d+/\d+/\d+\s+`)
-				timeStr = re.ReplaceAllString(timeStr, "")
-
-				// Check if this row has valid time data
-				if matched, _ := regexp.MatchString(`\d+:\d+`, timeStr); !matched || gameNum == "" {
-					return
+			if cells.Length() <= columns.maxIndex() {
+				return
+			}
+			cell := func(index int) string {
+				if index < 0 {
+					return ""
 				}
+				return strings.TrimSpace(cells.Eq(index).Text())
+			}
 
-				// Determine opponent based on whether our team is home or away
-				opponent := ""
-				homeAway := ""
-				score := ""
-				result := ""
-
-				if visitor == htmlName {
-					opponent = home
-					homeAway = "Away"
-					if visitorScore != "×" && homeScore != "×" && visitorScore != "" && homeScore != "" {
-						// We are visitor, so our score is visitorScore
-						ourScore, _ := strconv.Atoi(visitorScore)
-						theirScore, _ := strconv.Atoi(homeScore)
-						if ourScore > theirScore {
-							result = "W"
-						} else {
-							result = "L"
-						}
-						score = fmt.Sprintf("%s %s-%s", result, visitorScore, homeScore)
-					} else {
-						score = ""
-					}
-				} else if home == htmlName {
-					opponent = visitor
-					homeAway = "Home"
-					if visitorScore != "×" && homeScore != "×" && visitorScore != "" && homeScore != "" {
-						// We are home, so our score is homeScore
-						ourScore, _ := strconv.Atoi(homeScore)
-						theirScore, _ := strconv.Atoi(visitorScore)
-						if ourScore > theirScore {
-							score = fmt.Sprintf("W %s-%s", homeScore, visitorScore)
-							result = "W"
-						} else {
-							score = fmt.Sprintf("L %s-%s", homeScore, visitorScore)
-							result = "L"
-						}
-					} else {
-						score = ""
-					}
-				} else {
-					// Skip this row if it doesn't contain our team
-					return
+			gameNum := cell(columns.Game)
+			timeStr := cell(columns.Time)
+
+			// Times may carry their own date (e.g., "Sat 10/18/25 6:00 PM"); use it
+			// when there's no date header, then drop it
+			date := currentDate
+			if match := scheduleTimeDateRegex.FindStringSubmatch(timeStr); match != nil {
+				if dateObj := parseDateForSorting(match[1]); date == "" && dateObj.Year() != 2099 {
+					date = dateObj.Format("Monday, January 2, 2006")
 				}
+				timeStr = strings.TrimSpace(timeStr[len(match[0]):])
+			}
 
-				// Find location by name (TourneyMachine uses full location names)
-				loc, courtGymInfo := findLocationByName(location)
-
-				games = append(games, Game{
-					Team:         findTeamByName(displayName),
-					Date:         currentDate,
-					Time:         timeStr,
-					Location:     loc,
-					CourtGymInfo: courtGymInfo,
-					Opponent:     opponent,
-					HomeAway:     homeAway,
-					Score:        score,
-					Result:       result,
-				})
+			// Check if this row has valid time data
+			if matched, _ := regexp.MatchString(`\d+:\d+`, timeStr); !matched || gameNum == "" || date == "" {
+				return
 			}
+
+			rows = append(rows, tourneyMachineRow{
+				GameNum:      gameNum,
+				Date:         date,
+				Time:         timeStr,
+				Location:     cell(columns.Location),
+				Visitor:      cell(columns.Visitor),
+				VisitorScore: cell(columns.VisitorScore),
+				HomeScore:    cell(columns.HomeScore),
+				Home:         cell(columns.Home),
+				Round:        currentRound,
+			})
 		})
 	})
 
-	return games, nil
-}
-
-func parseDateForSorting(dateStr string) time.Time {
-	// Handle format like "Saturday, October 18, 2025"
-	layouts := []string{
-		"Monday, January 2, 2006",
-		"Monday, January 02, 2006",
-		"1/2/2006",
-		"01/02/2006",
-		"1/2/06",
-	}
-
-	for _, layout := range layouts {
-		if date, err := time.Parse(layout, dateStr); err == nil {
-			return date
+	// A page we could read but found no games on usually means the layout changed
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("parsed %s page but found no games (%s)", team.Name, url)
+	}
+
+	// Index rows by game number so bracket placeholders can be followed, and
+	// flag names that look like ours but match none of our teams
+	rowsByNum := make(map[string]*tourneyMachineRow)
+	unmatched := make(map[string]bool)
+	for i := range rows {
+		rowsByNum[strings.TrimPrefix(rows[i].GameNum, "#")] = &rows[i]
+		for _, name := range []string{rows[i].Visitor, rows[i].Home} {
+			if looksLikeOurs(name) && !unmatched[nameKey(name)] {
+				unmatched[nameKey(name)] = true
+				addBuildWarning("%s page lists %q, which matches no team name or alias (%s)", team.Name, name, url)
+			}
 		}
 	}
 
-	// If parsing fails, return far future date
-	return time.Date(2099, 12, 31, 0, 0, 0, 0, time.UTC)
-}
+	var games []Game
+	for _, row := range rows {
+		// Determine opponent based on whether our team is home or away,
+		// following bracket placeholders ("Winner of Game 12") back to our games
+		opponent := ""
+		homeAway := ""
+		score := ""
+		result := ""
+		var conditions []string
 
-func parseTimeToMinutes(timeStr string) int {
-	// Parse time like "6:00 PM" or "10:30 AM"
-	re := regexp.MustCompile(`(\d+):(\d+)\s*(AM|PM)`)
-	match := re.FindStringSubmatch(timeStr)
-	if len(match) == 4 {
-		hours, _ := strconv.Atoi(match[1])
-		minutes, _ := strconv.Atoi(match[2])
-		ampm := strings.ToUpper(match[3])
+		if visitorPath, ok := bracketPath(row.Visitor, team, rowsByNum, 0); ok {
+			opponent = row.Home
+			homeAway = "Away"
+			conditions = visitorPath
+			if row.VisitorScore != "×" && row.HomeScore != "×" && row.VisitorScore != "" && row.HomeScore != "" {
+				// We are visitor, so our score is visitorScore
+				ourScore, _ := strconv.Atoi(row.VisitorScore)
+				theirScore, _ := strconv.Atoi(row.HomeScore)
+				if ourScore > theirScore {
+					result = "W"
+				} else {
+					result = "L"
+				}
+				score = fmt.Sprintf("%s %s-%s", result, row.VisitorScore, row.HomeScore)
+			}
+		} else if homePath, ok := bracketPath(row.Home, team, rowsByNum, 0); ok {
+			opponent = row.Visitor
+			homeAway = "Home"
+			conditions = homePath
+			if row.VisitorScore != "×" && row.HomeScore != "×" && row.VisitorScore != "" && row.HomeScore != "" {
+				// We are home, so our score is homeScore
+				ourScore, _ := strconv.Atoi(row.HomeScore)
+				theirScore, _ := strconv.Atoi(row.VisitorScore)
+				if ourScore > theirScore {
+					score = fmt.Sprintf("W %s-%s", row.HomeScore, row.VisitorScore)
+					result = "W"
+				} else {
+					score = fmt.Sprintf("L %s-%s", row.HomeScore, row.VisitorScore)
+					result = "L"
+				}
+			}
+		} else {
+			// Skip this row if it doesn't contain our team
+			continue
+		}
 
-		if ampm == "PM" && hours != 12 {
-			hours += 12
-		} else if ampm == "AM" && hours == 12 {
-			hours = 0
+		// A possible game can't have been played by us yet
+		condition := ""
+		if len(conditions) > 0 {
+			condition = "If " + team.Name + " " + strings.Join(conditions, " and ")
+			score = ""
+			result = ""
 		}
 
-		return hours*60 + minutes
+		// Find location by name (TourneyMachine uses full location names)
+		loc, courtGymInfo := findLocationByName(row.Location)
+
+		games = append(games, Game{
+			Team:         team,
+			Date:         row.Date,
+			Time:         row.Time,
+			Location:     loc,
+			CourtGymInfo: courtGymInfo,
+			Opponent:     opponent,
+			HomeAway:     homeAway,
+			Score:        score,
+			Result:       result,
+			Tournament:   tournament,
+			Division:     division,
+			Round:        row.Round,
+			Condition:    condition,
+			SourceID:     "tm-" + tournamentID + "-" + slugify(row.GameNum),
+		})
 	}
-	return 9999 // Default to end of day if can't parse
-}
 
-// formatTime removes unnecessary :00
-// Examples: "4:00 PM" -> "4PM", "9:30 AM" -> "9:30AM"
-func formatTime(timeStr string) string {
-	if timeStr == "" || timeStr == "TBD" {
-		return timeStr
+	if len(games) == 0 {
+		return nil, fmt.Errorf("found %d games on %s page but none for the team (%s)", len(rows), team.Name, url)
 	}
 
-	// Match time pattern like "4:00 PM" or "9:30 AM"
-	re := regexp.MustCompile(`(\d+):(\d+)\s*(AM|PM)`)
+	return games, nil
+}
+
+// fetchSchedulePage downloads and parses a team's schedule page
+func fetchSchedulePage(team *Team, url string) (*goquery.Document, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	// Create request with browser-like headers to avoid Cloudflare blocking
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	req.Header.Set("Connection", "keep-alive")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s: %v", team.Name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("received status code %d for %s", resp.StatusCode, team.Name)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing HTML: %v", err)
+	}
+
+	return doc, nil
+}
+
+// isBracketPlaceholder reports whether a participant is a bracket slot
+// ("Winner of Game 12") rather than a team
+func isBracketPlaceholder(name string) bool {
+	return bracketPlaceholderRegex.MatchString(strings.TrimSpace(name))
+}
+
+// bracketPath reports whether a TourneyMachine participant is our team,
+// either directly or through a chain of bracket placeholders such as
+// "Winner of Game 12". It returns what has to happen first, e.g.
+// ["wins Game 12"]; the list is empty when the participant is us.
+// Results already posted for the referenced games are taken into account.
+func bracketPath(participant string, team *Team, rowsByNum map[string]*tourneyMachineRow, depth int) ([]string, bool) {
+	if team.MatchesName(participant) {
+		return nil, true
+	}
+
+	match := bracketPlaceholderRegex.FindStringSubmatch(participant)
+	if match == nil || depth > 10 {
+		return nil, false
+	}
+	needWin := strings.HasPrefix(strings.ToLower(match[1]), "w")
+	source := rowsByNum[match[2]]
+	if source == nil {
+		return nil, false
+	}
+
+	// Are we (possibly) in the referenced game, and on which side?
+	path, ok := bracketPath(source.Visitor, team, rowsByNum, depth+1)
+	ourScore, theirScore := source.VisitorScore, source.HomeScore
+	if !ok {
+		path, ok = bracketPath(source.Home, team, rowsByNum, depth+1)
+		ourScore, theirScore = source.HomeScore, source.VisitorScore
+	}
+	if !ok {
+		return nil, false
+	}
+
+	// Once the referenced game is final, the placeholder is decided
+	ours, err1 := strconv.Atoi(ourScore)
+	theirs, err2 := strconv.Atoi(theirScore)
+	if len(path) == 0 && err1 == nil && err2 == nil {
+		if (ours > theirs) != needWin {
+			return nil, false
+		}
+		return nil, true
+	}
+
+	if needWin {
+		return append(path, "wins Game "+source.GameNum), true
+	}
+	return append(path, "loses Game "+source.GameNum), true
+}
+
+// tourneyMachineHeadings reads the tournament and division names from a
+// TourneyMachine team page's heading, falling back to the page title
+func tourneyMachineHeadings(doc *goquery.Document) (tournament, division string) {
+	text := func(selectors ...string) string {
+		for _, selector := range selectors {
+			if t := strings.Join(strings.Fields(doc.Find(selector).First().Text()), " "); t != "" {
+				return t
+			}
+		}
+		return ""
+	}
+
+	tournament = text("[id$='TournamentName']", ".tournament-name", ".tournamentName")
+	division = text("[id$='DivisionName']", ".division-name", ".divisionName")
+	if tournament == "" {
+		// Titles look like "Fall Classic - 10U Girls - TourneyMachine"
+		parts := strings.Split(strings.TrimSpace(doc.Find("title").First().Text()), " - ")
+		if len(parts) > 0 && strings.EqualFold(strings.TrimSpace(parts[len(parts)-1]), "TourneyMachine") {
+			parts = parts[:len(parts)-1]
+		}
+		if len(parts) > 0 {
+			tournament = strings.TrimSpace(parts[0])
+		}
+		if division == "" && len(parts) > 1 {
+			division = strings.TrimSpace(parts[1])
+		}
+	}
+	return tournament, division
+}
+
+// scheduleColumnsFromHeader maps a schedule table's columns from a header row
+// naming at least Game, Time, Visitor and Home. Score columns are the ones
+// labeled "Score", or else the unlabeled columns next to the team names.
+func scheduleColumnsFromHeader(row *goquery.Selection) (scheduleColumns, bool) {
+	columns := scheduleColumns{Game: -1, Time: -1, Location: -1, Visitor: -1, VisitorScore: -1, HomeScore: -1, Home: -1}
+	var scores []int
+	var labels []string
+
+	index := 0
+	row.Children().Each(func(_ int, cell *goquery.Selection) {
+		label := strings.ToLower(strings.Join(strings.Fields(cell.Text()), " "))
+		switch {
+		case label == "game" || label == "game #" || label == "#":
+			columns.Game = index
+		case label == "time" || label == "date/time" || label == "date & time":
+			columns.Time = index
+		case strings.Contains(label, "location") || label == "court" || label == "venue":
+			columns.Location = index
+		case strings.Contains(label, "score") && strings.Contains(label, "home"):
+			columns.HomeScore = index
+		case strings.Contains(label, "score") && (strings.Contains(label, "visitor") || strings.Contains(label, "away")):
+			columns.VisitorScore = index
+		case strings.Contains(label, "score"):
+			scores = append(scores, index)
+		case label == "visitor" || label == "away":
+			columns.Visitor = index
+		case label == "home":
+			columns.Home = index
+		}
+
+		// Cells spanning several columns shift the ones after them
+		span, err := strconv.Atoi(cell.AttrOr("colspan", "1"))
+		if err != nil || span < 1 {
+			span = 1
+		}
+		for i := 0; i < span; i++ {
+			labels = append(labels, label)
+		}
+		index += span
+	})
+
+	if columns.Game < 0 || columns.Time < 0 || columns.Visitor < 0 || columns.Home < 0 {
+		return columns, false
+	}
+
+	switch {
+	case columns.VisitorScore >= 0 || columns.HomeScore >= 0:
+		// Scores are labeled with their side
+	case len(scores) == 2:
+		columns.VisitorScore, columns.HomeScore = scores[0], scores[1]
+	default:
+		// Unlabeled score columns follow the visitor and precede the home team
+		unlabeled := func(i int) bool { return i >= 0 && i < len(labels) && labels[i] == "" }
+		if unlabeled(columns.Visitor + 1) {
+			columns.VisitorScore = columns.Visitor + 1
+		}
+		if unlabeled(columns.Home-1) && columns.Home-1 != columns.VisitorScore {
+			columns.HomeScore = columns.Home - 1
+		}
+	}
+	return columns, true
+}
+
+// tourneyMachineTournamentID returns the IDTournament query parameter of a
+// TourneyMachine URL, falling back to a short hash of the URL itself.
+// Game numbers are only unique within a tournament, so this scopes them.
+func tourneyMachineTournamentID(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		for key, values := range u.Query() {
+			if strings.EqualFold(key, "IDTournament") && len(values) > 0 && values[0] != "" {
+				return slugify(values[0])
+			}
+		}
+	}
+	return shortHash(rawURL)
+}
+
+func parseDateForSorting(dateStr string) time.Time {
+	// Handle format like "Saturday, October 18, 2025"
+	layouts := []string{
+		"Monday, January 2, 2006",
+		"Monday, January 02, 2006",
+		"1/2/2006",
+		"01/02/2006",
+		"1/2/06",
+	}
+
+	for _, layout := range layouts {
+		if date, err := time.Parse(layout, dateStr); err == nil {
+			return date
+		}
+	}
+
+	// If parsing fails, return far future date
+	return time.Date(2099, 12, 31, 0, 0, 0, 0, time.UTC)
+}
+
+func parseTimeToMinutes(timeStr string) int {
+	// Parse time like "6:00 PM" or "10:30 AM"
+	re := regexp.MustCompile(`(\d+):(\d+)\s*(AM|PM)`)
+	match := re.FindStringSubmatch(timeStr)
+	if len(match) == 4 {
+		hours, _ := strconv.Atoi(match[1])
+		minutes, _ := strconv.Atoi(match[2])
+		ampm := strings.ToUpper(match[3])
+
+		if ampm == "PM" && hours != 12 {
+			hours += 12
+		} else if ampm == "AM" && hours == 12 {
+			hours = 0
+		}
+
+		return hours*60 + minutes
+	}
+	return 9999 // Default to end of day if can't parse
+}
+
+// formatTime removes unnecessary :00
+// Examples: "4:00 PM" -> "4PM", "9:30 AM" -> "9:30AM"
+func formatTime(timeStr string) string {
+	if timeStr == "" || timeStr == "TBD" {
+		return timeStr
+	}
+
+	// Match time pattern like "4:00 PM" or "9:30 AM"
+	re := regexp.MustCompile(`(\d+):(\d+)\s*(AM|PM)`)
 	match := re.FindStringSubmatch(timeStr)
 	if len(match) == 4 {
 		hours := match[1]
@@ -725,6 +1247,30 @@ func convertLinksToHTML(text string) string {
 	})
 }
 
+// Zone returns the timezone the game is played in
+func (g Game) Zone() *time.Location {
+	if g.Location != nil && g.Location.Timezone != nil {
+		return g.Location.Timezone
+	}
+	return HomeLocation
+}
+
+// Start returns when the game tips off, interpreted in the game's timezone.
+// ok is false when the date or time is TBD or can't be parsed.
+func (g Game) Start() (start time.Time, ok bool) {
+	dateObj := parseDateForSorting(g.Date)
+	if dateObj.Year() == 2099 {
+		return time.Time{}, false
+	}
+
+	minutes := parseTimeToMinutes(g.Time)
+	if minutes == 9999 {
+		return time.Time{}, false
+	}
+
+	return time.Date(dateObj.Year(), dateObj.Month(), dateObj.Day(), minutes/60, minutes%60, 0, 0, g.Zone()), true
+}
+
 func (g Game) IsPastGame(gameDate time.Time, now time.Time) bool {
 	// A game is considered past if:
 	// 1. It has a result (W or L), OR
@@ -734,43 +1280,28 @@ func (g Game) IsPastGame(gameDate time.Time, now time.Time) bool {
 	return g.Result != "" || (gameDate.Year() != 2099 && gameDate.Before(startOfToday))
 }
 
-func generateHTML(allGames []Game, allNotes []Note, outputFile string, filterTeam *Team) error {
+func generateHTML(allGames []Game, allNotes []Note, outputFile string, filter Filter) error {
+	// Create output file
+	f, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer f.Close()
+
+	return renderHTML(f, allGames, allNotes, filter)
+}
+
+// renderHTML writes the schedule page for a view
+func renderHTML(w io.Writer, allGames []Game, allNotes []Note, filter Filter) error {
 	// Parse the embedded template
 	tmpl, err := template.New("schedule").Parse(scheduleTemplate)
 	if err != nil {
 		return fmt.Errorf("error parsing template: %v", err)
 	}
 
-	// Filter games if a specific team is requested
-	var gamesToDisplay []Game
-	if filterTeam != nil {
-		for _, game := range allGames {
-			if game.Team.Slug == filterTeam.Slug {
-				gamesToDisplay = append(gamesToDisplay, game)
-			}
-		}
-	} else {
-		gamesToDisplay = allGames
-	}
-
-	// Filter notes based on the team filter
-	var notesToDisplay []Note
-	for _, note := range allNotes {
-		// For combined schedule (no filter), show all notes
-		if filterTeam == nil {
-			notesToDisplay = append(notesToDisplay, note)
-		} else {
-			// For team pages, only show notes that:
-			// 1. Have "All Teams" in the Teams column (case-insensitive), OR
-			// 2. Have the team name in the Teams column
-			teamsLower := strings.ToLower(note.Teams)
-			filterTeamLower := strings.ToLower(filterTeam.Name)
-
-			if teamsLower == "all teams" || strings.Contains(teamsLower, filterTeamLower) {
-				notesToDisplay = append(notesToDisplay, note)
-			}
-		}
-	}
+	// Select the games and notes that belong in this view
+	gamesToDisplay := filter.Games(allGames)
+	notesToDisplay := filter.Notes(allNotes)
 
 	// Create combined list of schedule items (games and notes)
 	var scheduleItems []ScheduleItem
@@ -866,6 +1397,36 @@ func generateHTML(allGames []Game, allNotes []Note, outputFile string, filterTea
 		return !isTBDA
 	})
 
+	// Summary views group each day's games by venue, then by time
+	if filter.GroupByLocation {
+		sort.SliceStable(scheduleItems, func(i, j int) bool {
+			a, b := scheduleItems[i], scheduleItems[j]
+			dateA, dateB := scheduleItemDate(a), scheduleItemDate(b)
+			if !dateA.Equal(dateB) {
+				return dateA.Before(dateB)
+			}
+			if a.IsNote || b.IsNote {
+				// Notes stay at the top of their date
+				return a.IsNote && !b.IsNote
+			}
+			nameA, nameB := locationSortName(a.Game.Location), locationSortName(b.Game.Location)
+			if nameA != nameB {
+				return nameA < nameB
+			}
+			return parseTimeToMinutes(a.Game.Time) < parseTimeToMinutes(b.Game.Time)
+		})
+	}
+
+	// Flag games that overlap another team's game in the same view
+	var warnings map[*Game]string
+	if filter.FlagConflicts {
+		var games []*Game
+		for i := range gamesToDisplay {
+			games = append(games, &gamesToDisplay[i])
+		}
+		warnings = findOverlaps(games)
+	}
+
 	// Get unique teams and sort by their Order field
 	teamSet := make(map[*Team]bool)
 	for _, game := range allGames {
@@ -889,22 +1450,20 @@ func generateHTML(allGames []Game, allNotes []Note, outputFile string, filterTea
 	pagePath := "/"
 	teamRecord := ""
 
-	if filterTeam != nil {
-		pageTitle = filterTeam.Name
-		pagePath = "/" + filterTeam.Slug + "/"
-
-		// Calculate W-L record for team pages
-		wins := 0
-		losses := 0
-		for _, game := range gamesToDisplay {
-			if game.Result == "W" {
-				wins++
-			} else if game.Result == "L" {
-				losses++
-			}
-		}
-		if wins > 0 || losses > 0 {
-			teamRecord = fmt.Sprintf(" [%d-%d]", wins, losses)
+	if filter.Title != "" {
+		pageTitle = filter.Title
+	}
+	if filter.Path != "" {
+		pagePath = filter.Path
+	}
+
+	var stats *TeamStats
+	if len(filter.Teams) == 1 {
+		// Calculate W-L record and stats for team pages
+		teamStats := computeTeamStats(filter.Teams[0], gamesToDisplay)
+		if teamStats.Wins > 0 || teamStats.Losses > 0 {
+			teamRecord = fmt.Sprintf(" [%s]", teamStats.Record())
+			stats = &teamStats
 		}
 	}
 
@@ -914,7 +1473,7 @@ func generateHTML(allGames []Game, allNotes []Note, outputFile string, filterTea
 	for _, team := range teams {
 		teamButtons = append(teamButtons, TeamButton{
 			Team:     team,
-			IsActive: (filterTeam != nil && (filterTeam.Name == team.Name)),
+			IsActive: len(filter.Teams) == 1 && filter.HasTeam(team),
 		})
 	}
 
@@ -947,6 +1506,45 @@ func generateHTML(allGames []Game, allNotes []Note, outputFile string, filterTea
 
 		game := item.Game
 
+		// Start a new group when the day or venue changes
+		if filter.GroupByLocation {
+			prev := -1
+			for j := i - 1; j >= 0; j-- {
+				if !scheduleItems[j].IsNote {
+					prev = j
+					break
+				}
+			}
+			if prev == -1 ||
+				scheduleItems[prev].Game.Date != game.Date ||
+				scheduleItems[prev].Game.Location != game.Location {
+				templateItems = append(templateItems, TemplateScheduleItem{
+					IsGroupHeader: true,
+					GroupHTML:     groupHeaderHTML(game),
+				})
+			}
+		}
+
+		// Start a collapsible tournament group when a run of tournament games begins
+		tournamentKey := ""
+		if game.Tournament != "" && !filter.GroupByLocation {
+			tournamentKey = slugify(game.Tournament)
+			prev := -1
+			for j := i - 1; j >= 0; j-- {
+				if !scheduleItems[j].IsNote {
+					prev = j
+					break
+				}
+			}
+			if prev == -1 || scheduleItems[prev].Game.Tournament != game.Tournament {
+				templateItems = append(templateItems, TemplateScheduleItem{
+					IsGroupHeader: true,
+					GroupHTML:     tournamentHeaderHTML(game, len(filter.Teams) == 1),
+					Tournament:    tournamentKey,
+				})
+			}
+		}
+
 		// Determine if this is the first game of a new calendar week
 		isWeekStart := false
 		currentDate := parseDateForSorting(game.Date)
@@ -982,37 +1580,36 @@ func generateHTML(allGames []Game, allNotes []Note, outputFile string, filterTea
 				displayDateTime = fmt.Sprintf("%s %s %d TBD", weekday, month, day)
 			} else {
 				displayDateTime = fmt.Sprintf("%s %s %d %s", weekday, month, day, timeFormatted)
+
+				// Call out local time for games outside the home timezone (e.g., "11AM MDT")
+				if start, ok := game.Start(); ok && game.Zone().String() != homeTimezone {
+					displayDateTime += " " + start.Format("MST")
+				}
 			}
 		}
 
-		// Generate location HTML with Google Maps link if address is available
-		var locationHTML template.HTML
+		// Generate location HTML with a map link if coordinates or an address are available
+		var locHTML template.HTML
 		if game.Location != nil {
-			var locDisplay string
-			if isPresent(game.Location.Address) {
-				// Location has an address - make it a Google Maps link
-				mapsURL := "https://maps.google.com/?q=" +
-					strings.ReplaceAll(game.Location.Address, " ", "+")
-				locDisplay = fmt.Sprintf(`<a href="%s" target="_blank">%s</a>`,
-					mapsURL, game.Location.Abbrev)
-			} else {
-				// Location has no address - just show the abbreviation
-				locDisplay = game.Location.Abbrev
-			}
+			locDisplay := locationHTML(game.Location, game.Location.Abbrev)
 
 			// Add court/gym info if present
 			if game.CourtGymInfo != "" {
-				locationHTML = template.HTML(fmt.Sprintf("%s (%s)", locDisplay, strings.ToLower(game.CourtGymInfo)))
+				locHTML = template.HTML(fmt.Sprintf("%s (%s)", locDisplay, template.HTMLEscapeString(strings.ToLower(game.CourtGymInfo))))
 			} else {
-				locationHTML = template.HTML(locDisplay)
+				locHTML = template.HTML(locDisplay)
 			}
 		} else {
-			locationHTML = template.HTML("TBD")
+			locHTML = template.HTML("TBD")
 		}
 
 		opponent := game.Opponent
+		opponentSlug := ""
 		if opponent == "" {
 			opponent = "TBD"
+		} else if game.Condition == "" && OpponentPages[slugify(opponent)] {
+			// Possible bracket games and TBDs have no meetings, so no page
+			opponentSlug = slugify(opponent)
 		}
 		score := game.Score
 		if score == "-" {
@@ -1025,9 +1622,12 @@ func generateHTML(allGames []Game, allNotes []Note, outputFile string, filterTea
 			IsPastGame:      game.IsPastGame(dateObj, now),
 			Game:            game,
 			DisplayDateTime: displayDateTime,
-			LocationHTML:    locationHTML,
-			JerseyText:      formatJersey(game, "html"),
+			LocationHTML:    locHTML,
+			JerseyHTML:      jerseyHTML(game),
+			Warning:         gameWarning(game, warnings[game]),
+			Tournament:      tournamentKey,
 			OpponentDisplay: opponent,
+			OpponentSlug:    opponentSlug,
 			ScoreDisplay:    score,
 		})
 	}
@@ -1039,22 +1639,181 @@ func generateHTML(allGames []Game, allNotes []Note, outputFile string, filterTea
 		ProdDomain:     domain,
 		UpdatedUTC:     now.Format(time.RFC3339),
 		UpdatedDisplay: now.Format("1/2/06") + " at " + now.Format("3:04PM") + " UTC",
-		IsAllTeams:     filterTeam == nil,
+		TimeZone:       homeTimezone,
+		IsAllTeams:     filter.IsAll(),
 		TeamRecord:     teamRecord,
+		Stats:          stats,
 		Teams:          teamButtons,
 		ScheduleItems:  templateItems,
 		StylesCSS:      template.CSS(stylesCSS),
 		ScheduleJS:     template.JS(scheduleJS),
 	}
 
-	// Create output file
+	// Execute template
+	err = tmpl.Execute(w, data)
+	if err != nil {
+		return fmt.Errorf("error executing template: %v", err)
+	}
+
+	return nil
+}
+
+// gameWarning combines the game's group conflicts with any found in the view
+func gameWarning(game *Game, viewWarning string) string {
+	warnings := game.Warnings
+	if viewWarning != "" && len(warnings) == 0 {
+		warnings = append(warnings, viewWarning)
+	}
+	return strings.Join(warnings, "; ")
+}
+
+// scheduleItemDate returns the date a game or note is listed under
+func scheduleItemDate(item ScheduleItem) time.Time {
+	if item.IsNote {
+		return parseDateForSorting(item.Note.Date)
+	}
+	return parseDateForSorting(item.Game.Date)
+}
+
+// locationSortName orders venues by name with TBD locations last
+func locationSortName(location *Location) string {
+	if location == nil {
+		return "\uffff"
+	}
+	return location.Name
+}
+
+// groupHeaderHTML renders a summary view's group header, e.g. "Saturday, October 18 · Ralston Arena"
+func groupHeaderHTML(game *Game) template.HTML {
+	day := game.Date
+	if date := parseDateForSorting(game.Date); date.Year() != 2099 {
+		day = date.Format("Monday, January 2")
+	}
+
+	venue := "Location TBD"
+	if game.Location != nil {
+		venue = locationHTML(game.Location, game.Location.Name)
+	}
+	return template.HTML(template.HTMLEscapeString(day) + " &middot; " + venue)
+}
+
+// tournamentHeaderHTML renders a tournament group header, e.g. "🏆 Fall Classic · 10U Girls".
+// The division is only shown on single-team pages, where it's the same for every game.
+func tournamentHeaderHTML(game *Game, showDivision bool) template.HTML {
+	header := "🏆 " + template.HTMLEscapeString(game.Tournament)
+	if showDivision && game.Division != "" {
+		header += " &middot; " + template.HTMLEscapeString(game.Division)
+	}
+	return template.HTML(header)
+}
+
+// weekendRange returns the Friday–Sunday window to show on the weekend page:
+// the current one from Friday through Sunday, otherwise the next one
+func weekendRange(now time.Time) (time.Time, time.Time) {
+	today := dateOnly(now)
+	var friday time.Time
+	switch now.Weekday() {
+	case time.Friday, time.Saturday:
+		friday = today.AddDate(0, 0, -int(now.Weekday()-time.Friday))
+	case time.Sunday:
+		friday = today.AddDate(0, 0, -2)
+	default:
+		friday = today.AddDate(0, 0, int(time.Friday-now.Weekday()))
+	}
+	return friday, friday.AddDate(0, 0, 2)
+}
+
+// WeekendFilter is every team's games this weekend, grouped by venue
+func WeekendFilter(now time.Time) Filter {
+	from, to := weekendRange(now)
+	return Filter{Title: "This Weekend", Path: "/weekend/", From: from, To: to, GroupByLocation: true}
+}
+
+// TodayFilter is every team's games today, grouped by venue
+func TodayFilter(now time.Time) Filter {
+	return Filter{Title: "Today", Path: "/today/", From: now, To: now, GroupByLocation: true}
+}
+
+// generateView writes the HTML page and iCal feed for a view into the
+// directory matching its path
+func generateView(allGames []Game, allNotes []Note, distDir string, view Filter) error {
+	viewDir := filepath.Join(distDir, filepath.FromSlash(strings.Trim(view.Path, "/")))
+	err := os.MkdirAll(viewDir, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating directory: %v", err)
+	}
+
+	err = generateHTML(allGames, allNotes, filepath.Join(viewDir, "index.html"), view)
+	if err != nil {
+		return err
+	}
+
+	return generateICalendar(allGames, allNotes, filepath.Join(viewDir, "schedule.ics"), view)
+}
+
+// generateLocationsIndex writes a page listing every location with its
+// address, map links and number of upcoming games
+func generateLocationsIndex(allGames []Game, outputFile string) error {
+	tmpl, err := template.New("locations").Parse(locationsTemplate)
+	if err != nil {
+		return fmt.Errorf("error parsing template: %v", err)
+	}
+
+	now := time.Now().UTC()
+
+	upcoming := make(map[*Location]int)
+	teamSet := make(map[*Team]bool)
+	for _, game := range allGames {
+		teamSet[game.Team] = true
+		if game.Location != nil && !game.IsPastGame(parseDateForSorting(game.Date), now) {
+			upcoming[game.Location]++
+		}
+	}
+
+	var teamButtons []TeamButton
+	for i := range AllTeams {
+		if teamSet[&AllTeams[i]] {
+			teamButtons = append(teamButtons, TeamButton{Team: &AllTeams[i]})
+		}
+	}
+
+	var locations []LocationSummary
+	for i := range AllLocations {
+		loc := &AllLocations[i]
+		addressHTML := template.HTML("")
+		if loc.Address != "" || loc.HasGeo {
+			label := loc.Address
+			if label == "" {
+				label = "Map"
+			}
+			addressHTML = template.HTML(locationHTML(loc, label))
+		}
+		locations = append(locations, LocationSummary{
+			Location:      loc,
+			Slug:          loc.Slug(),
+			AddressHTML:   addressHTML,
+			UpcomingGames: upcoming[loc],
+		})
+	}
+
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].Location.Name < locations[j].Location.Name
+	})
+
+	data := LocationsTemplateData{
+		PageTitle:      "Lightning Game Locations",
+		UpdatedDisplay: now.Format("1/2/06") + " at " + now.Format("3:04PM") + " UTC",
+		Teams:          teamButtons,
+		Locations:      locations,
+		StylesCSS:      template.CSS(stylesCSS),
+	}
+
 	f, err := os.Create(outputFile)
 	if err != nil {
 		return fmt.Errorf("error creating file: %v", err)
 	}
 	defer f.Close()
 
-	// Execute template
 	err = tmpl.Execute(f, data)
 	if err != nil {
 		return fmt.Errorf("error executing template: %v", err)
@@ -1063,71 +1822,46 @@ func generateHTML(allGames []Game, allNotes []Note, outputFile string, filterTea
 	return nil
 }
 
-func generateICalendar(allGames []Game, allNotes []Note, outputFile string, filterTeam *Team) error {
-	// Filter games if a specific team is requested
-	var gamesToExport []Game
-	if filterTeam != nil {
-		for _, game := range allGames {
-			if game.Team.Slug == filterTeam.Slug {
-				gamesToExport = append(gamesToExport, game)
-			}
-		}
-	} else {
-		gamesToExport = allGames
+func generateICalendar(allGames []Game, allNotes []Note, outputFile string, filter Filter) error {
+	output, err := renderICalendar(allGames, allNotes, filter)
+	if err != nil {
+		return err
 	}
 
-	// Filter notes based on the team filter
-	var notesToExport []Note
-	for _, note := range allNotes {
-		// For combined schedule (no filter), show all notes
-		if filterTeam == nil {
-			notesToExport = append(notesToExport, note)
-		} else {
-			// For team calendars, only show notes that:
-			// 1. Have "All Teams" in the Teams column (case-insensitive), OR
-			// 2. Have the team name in the Teams column
-			teamsLower := strings.ToLower(note.Teams)
-			filterTeamLower := strings.ToLower(filterTeam.Name)
-
-			if teamsLower == "all teams" || strings.Contains(teamsLower, filterTeamLower) {
-				notesToExport = append(notesToExport, note)
-			}
-		}
+	// Write to file
+	err = os.WriteFile(outputFile, output, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing iCal file: %v", err)
 	}
 
-	var ical strings.Builder
+	return nil
+}
+
+// renderICalendar builds and validates the iCal feed for a view
+func renderICalendar(allGames []Game, allNotes []Note, filter Filter) ([]byte, error) {
+	// Select the games and notes that belong in this view
+	gamesToExport := filter.Games(allGames)
+	notesToExport := filter.Notes(allNotes)
+
+	now := time.Now().UTC()
+	seenUIDs := make(map[string]int)
+	noteOrdinals := make(map[string]int)
 
 	// iCal header
-	ical.WriteString("BEGIN:VCALENDAR\r\n")
-	ical.WriteString("VERSION:2.0\r\n")
-	ical.WriteString("PRODID:-//Omaha Lightning//Basketball Schedule//EN\r\n")
-	ical.WriteString("CALSCALE:GREGORIAN\r\n")
-	ical.WriteString("METHOD:PUBLISH\r\n")
-	ical.WriteString("X-WR-CALNAME:Lightning Schedule")
-	if filterTeam != nil {
-		ical.WriteString(" - " + filterTeam.Name)
-	}
-	ical.WriteString("\r\n")
-	ical.WriteString("X-WR-TIMEZONE:America/Chicago\r\n")
-
-	// Central timezone definition
-	ical.WriteString("BEGIN:VTIMEZONE\r\n")
-	ical.WriteString("TZID:America/Chicago\r\n")
-	ical.WriteString("BEGIN:DAYLIGHT\r\n")
-	ical.WriteString("TZOFFSETFROM:-0600\r\n")
-	ical.WriteString("TZOFFSETTO:-0500\r\n")
-	ical.WriteString("DTSTART:19700308T020000\r\n")
-	ical.WriteString("RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU\r\n")
-	ical.WriteString("TZNAME:CDT\r\n")
-	ical.WriteString("END:DAYLIGHT\r\n")
-	ical.WriteString("BEGIN:STANDARD\r\n")
-	ical.WriteString("TZOFFSETFROM:-0500\r\n")
-	ical.WriteString("TZOFFSETTO:-0600\r\n")
-	ical.WriteString("DTSTART:19701101T020000\r\n")
-	ical.WriteString("RRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU\r\n")
-	ical.WriteString("TZNAME:CST\r\n")
-	ical.WriteString("END:STANDARD\r\n")
-	ical.WriteString("END:VTIMEZONE\r\n")
+	calName := "Lightning Schedule"
+	if filter.Title != "" {
+		calName += " - " + filter.Title
+	}
+	cal := ical.NewComponent("VCALENDAR").
+		Add("VERSION", "2.0").
+		Add("PRODID", "-//Omaha Lightning//Basketball Schedule//EN").
+		Add("CALSCALE", "GREGORIAN").
+		Add("METHOD", "PUBLISH").
+		AddText("X-WR-CALNAME", calName).
+		Add("X-WR-TIMEZONE", homeTimezone)
+
+	// Timezones used by timed games, always including home
+	zones := map[string]*time.Location{homeTimezone: HomeLocation}
 
 	// Add game events
 	for _, game := range gamesToExport {
@@ -1137,61 +1871,32 @@ func generateICalendar(allGames []Game, allNotes []Note, outputFile string, filt
 			continue // Skip games with invalid dates
 		}
 
-		// Parse time - determine if TBD
-		isTBD := game.Time == "TBD" || game.Time == ""
-
-		var startTime, endTime time.Time
+		// Timed games start in their location's timezone; anything else is all-day
+		startTime, isTimed := game.Start()
+		isTBD := !isTimed
 
+		var endTime time.Time
 		if isTBD {
 			// All-day event for TBD games
 			startTime = time.Date(dateObj.Year(), dateObj.Month(), dateObj.Day(), 0, 0, 0, 0, time.UTC)
 			endTime = startTime.Add(24 * time.Hour)
 		} else {
-			// Parse time like "6:00 PM" or "10:30 AM"
-			re := regexp.MustCompile(`(\d+):(\d+)\s*(AM|PM)`)
-			match := re.FindStringSubmatch(game.Time)
-			if len(match) == 4 {
-				hours, _ := strconv.Atoi(match[1])
-				minutes, _ := strconv.Atoi(match[2])
-				ampm := strings.ToUpper(match[3])
-
-				if ampm == "PM" && hours != 12 {
-					hours += 12
-				} else if ampm == "AM" && hours == 12 {
-					hours = 0
-				}
-
-				// Create time in Central timezone
-				centralLoc, _ := time.LoadLocation("America/Chicago")
-				startTime = time.Date(dateObj.Year(), dateObj.Month(), dateObj.Day(), hours, minutes, 0, 0, centralLoc)
-				// Assume games are 1 hour long
-				endTime = startTime.Add(1 * time.Hour)
-			} else {
-				// Fallback to all-day if time parsing fails
-				isTBD = true
-				startTime = time.Date(dateObj.Year(), dateObj.Month(), dateObj.Day(), 0, 0, 0, 0, time.UTC)
-				endTime = startTime.Add(24 * time.Hour)
-			}
+			// Assume games are 1 hour long
+			endTime = startTime.Add(gameDuration)
+			zones[startTime.Location().String()] = startTime.Location()
 		}
 
-		// Create event UID
-		uid := fmt.Sprintf("game-%s-%s-%s@lightningschedule.local",
-			strings.ReplaceAll(game.Team.Name, " ", ""),
-			dateObj.Format("20060102"),
-			strings.ReplaceAll(game.Time, " ", ""))
-
-		ical.WriteString("BEGIN:VEVENT\r\n")
-		ical.WriteString("UID:" + uid + "\r\n")
-		ical.WriteString("DTSTAMP:" + time.Now().UTC().Format("20060102T150405Z") + "\r\n")
+		event := ical.NewComponent("VEVENT")
 
 		if isTBD {
 			// All-day event format
-			ical.WriteString("DTSTART;VALUE=DATE:" + startTime.Format("20060102") + "\r\n")
-			ical.WriteString("DTEND;VALUE=DATE:" + endTime.Format("20060102") + "\r\n")
+			event.Add("DTSTART", startTime.Format("20060102"), "VALUE=DATE")
+			event.Add("DTEND", endTime.Format("20060102"), "VALUE=DATE")
 		} else {
 			// Timed event format
-			ical.WriteString("DTSTART;TZID=America/Chicago:" + startTime.Format("20060102T150405") + "\r\n")
-			ical.WriteString("DTEND;TZID=America/Chicago:" + endTime.Format("20060102T150405") + "\r\n")
+			tzid := "TZID=" + startTime.Location().String()
+			event.Add("DTSTART", startTime.Format("20060102T150405"), tzid)
+			event.Add("DTEND", endTime.Format("20060102T150405"), tzid)
 		}
 
 		// Event title
@@ -1199,31 +1904,75 @@ func generateICalendar(allGames []Game, allNotes []Note, outputFile string, filt
 		if game.HomeAway == "Away" {
 			summary = game.Team.Name + " @ " + game.Opponent
 		}
-		ical.WriteString("SUMMARY:" + escapeICalText(summary) + "\r\n")
+		if game.Round != "" {
+			summary += " (" + game.Round + ")"
+		}
+		if game.Condition != "" {
+			summary = "Possible: " + summary
+			event.Add("STATUS", "TENTATIVE")
+		}
+		event.AddText("SUMMARY", summary)
 
 		description := ""
+		if game.Tournament != "" {
+			description = game.Tournament
+			if game.Division != "" {
+				description += " - " + game.Division
+			}
+			description += "\n"
+		}
+		if game.Condition != "" {
+			description += game.Condition + "\n"
+		}
 		if game.CourtGymInfo != "" {
-			description = game.CourtGymInfo + "\n"
+			description += game.CourtGymInfo + "\n"
 		}
 
-		description += fmt.Sprintf("Jersey: %s", formatJersey(&game, "cal"))
+		description += fmt.Sprintf("Jersey: %s", jerseyCalendarText(&game))
 
 		if game.Score != "" && game.Score != "-" {
 			description += "\nScore: " + game.Score
 		}
-		ical.WriteString("DESCRIPTION:" + escapeICalText(description) + "\r\n")
-
 		if game.Location != nil {
-			location := game.Location.Name
+			if game.Location.Parking != "" {
+				description += "\nParking: " + game.Location.Parking
+			}
+			if game.Location.GymMap != "" {
+				description += "\nGym map: " + game.Location.GymMap
+			}
+		}
+		event.AddText("DESCRIPTION", description)
 
-			// Add address if present for better calendar app support
-			if game.Location.Address != "" {
-				location += ", " + game.Location.Address
+		// Calendar apps can filter and color tournament games by category
+		var categories []string
+		for _, category := range []string{game.Tournament, game.Division} {
+			if category != "" {
+				categories = append(categories, category)
+			}
+		}
+		if len(categories) > 0 {
+			event.AddTextList("CATEGORIES", categories)
+		}
+
+		if game.Location != nil {
+			// Include address if present for better calendar app support
+			event.AddText("LOCATION", game.Location.FullAddress())
+
+			if game.Location.HasGeo {
+				event.Add("GEO", fmt.Sprintf("%g;%g", game.Location.Latitude, game.Location.Longitude))
+
+				// Apple Calendar shows a map and travel time for structured locations
+				event.Add("X-APPLE-STRUCTURED-LOCATION",
+					fmt.Sprintf("geo:%g,%g", game.Location.Latitude, game.Location.Longitude),
+					"VALUE=URI",
+					"X-ADDRESS="+icalParamValue(game.Location.Address),
+					"X-APPLE-RADIUS=100",
+					"X-TITLE="+icalParamValue(game.Location.Name))
 			}
-			ical.WriteString("LOCATION:" + escapeICalText(location) + "\r\n")
 		}
 
-		ical.WriteString("END:VEVENT\r\n")
+		stampICalEvent(event, uniqueUID(gameUID(&game), seenUIDs), now)
+		cal.AddChild(event)
 	}
 
 	// Add note events (all-day events)
@@ -1245,11 +1994,6 @@ func generateICalendar(allGames []Game, allNotes []Note, outputFile string, filt
 		startTime := time.Date(dateObj.Year(), dateObj.Month(), dateObj.Day(), 0, 0, 0, 0, time.UTC)
 		endTime := time.Date(endDateObj.Year(), endDateObj.Month(), endDateObj.Day(), 0, 0, 0, 0, time.UTC).Add(24 * time.Hour)
 
-		// Create event UID
-		uid := fmt.Sprintf("note-%s-%s@lightningschedule.local",
-			dateObj.Format("20060102"),
-			fmt.Sprintf("%x", strings.ReplaceAll(note.Text, " ", "")))
-
 		// Split on | to separate summary from description (note.Text contains raw text)
 		parts := strings.Split(note.Text, "|")
 		summary := strings.TrimSpace(parts[0])
@@ -1263,34 +2007,236 @@ func generateICalendar(allGames []Game, allNotes []Note, outputFile string, filt
 			description = strings.Join(descParts, "\n")
 		}
 
-		ical.WriteString("BEGIN:VEVENT\r\n")
-		ical.WriteString("UID:" + uid + "\r\n")
-		ical.WriteString("DTSTAMP:" + time.Now().UTC().Format("20060102T150405Z") + "\r\n")
-		ical.WriteString("DTSTART;VALUE=DATE:" + startTime.Format("20060102") + "\r\n")
-		ical.WriteString("DTEND;VALUE=DATE:" + endTime.Format("20060102") + "\r\n")
-		ical.WriteString("SUMMARY:" + escapeICalText(summary) + "\r\n")
-		ical.WriteString("DESCRIPTION:" + escapeICalText(description) + "\r\n")
-		ical.WriteString("END:VEVENT\r\n")
+		event := ical.NewComponent("VEVENT").
+			Add("DTSTART", startTime.Format("20060102"), "VALUE=DATE").
+			Add("DTEND", endTime.Format("20060102"), "VALUE=DATE").
+			AddText("SUMMARY", summary).
+			AddText("DESCRIPTION", description)
+
+		stampICalEvent(event, uniqueUID(noteUID(&note, noteOrdinals), seenUIDs), now)
+		cal.AddChild(event)
 	}
 
-	ical.WriteString("END:VCALENDAR\r\n")
+	// Timezone definitions generated from the tz database for the years we
+	// cover, placed ahead of the events that reference them
+	from, to := calendarRange(gamesToExport)
+	var zoneNames []string
+	for name := range zones {
+		zoneNames = append(zoneNames, name)
+	}
+	sort.Strings(zoneNames)
+	var timezones []*ical.Component
+	for _, name := range zoneNames {
+		timezones = append(timezones, ical.Timezone(zones[name], from, to))
+	}
+	cal.Children = append(timezones, cal.Children...)
 
-	// Write to file
-	err := os.WriteFile(outputFile, []byte(ical.String()), 0644)
+	// Refuse to publish a feed calendar apps can't parse
+	output := []byte(cal.String())
+	if err := ical.Validate(output); err != nil {
+		return nil, fmt.Errorf("invalid iCal feed: %v", err)
+	}
+
+	return output, nil
+}
+
+// calendarRange returns the span of whole years covering every dated game,
+// used to limit VTIMEZONE transitions to the ones that matter
+func calendarRange(games []Game) (time.Time, time.Time) {
+	first, last := 0, 0
+	for _, game := range games {
+		year := parseDateForSorting(game.Date).Year()
+		if year == 2099 {
+			continue
+		}
+		if first == 0 || year < first {
+			first = year
+		}
+		if year > last {
+			last = year
+		}
+	}
+	if first == 0 {
+		first = time.Now().Year()
+		last = first
+	}
+	return time.Date(first, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(last+1, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// stampICalEvent prepends the UID, DTSTAMP, SEQUENCE and LAST-MODIFIED
+// tracked in EventRevisions. DTSTAMP follows the last modification rather than
+// the run time so unchanged events are byte-identical between runs.
+func stampICalEvent(event *ical.Component, uid string, now time.Time) {
+	var content []string
+	for _, prop := range event.Properties {
+		content = append(content, prop.String())
+	}
+	rev := reviseEvent(uid, strings.Join(content, "\n"), now)
+	stamp := rev.LastModified.UTC().Format("20060102T150405Z")
+
+	event.Properties = append([]ical.Property{
+		{Name: "UID", Value: uid},
+		{Name: "DTSTAMP", Value: stamp},
+		{Name: "SEQUENCE", Value: strconv.Itoa(rev.Sequence)},
+		{Name: "LAST-MODIFIED", Value: stamp},
+	}, event.Properties...)
+}
+
+// gameUID builds an event UID from the game's source identity so that time
+// or location changes update the existing calendar event
+func gameUID(game *Game) string {
+	id := game.SourceID
+	if id == "" {
+		// No source identity; team + date + opponent is the best we can do
+		id = fmt.Sprintf("%s-%s",
+			parseDateForSorting(game.Date).Format("20060102"),
+			slugify(game.Opponent))
+	}
+	return fmt.Sprintf("game-%s-%s@%s", slugify(game.Team.Slug), id, domain)
+}
+
+// noteUID builds an event UID from the note's ID column, falling back to
+// date + teams + position among that date's notes so text edits keep the UID
+func noteUID(note *Note, ordinals map[string]int) string {
+	if note.ID != "" {
+		return fmt.Sprintf("note-%s@%s", slugify(note.ID), domain)
+	}
+
+	key := fmt.Sprintf("%s-%s",
+		parseDateForSorting(note.Date).Format("20060102"),
+		slugify(note.Teams))
+	ordinals[key]++
+	if ordinals[key] > 1 {
+		key = fmt.Sprintf("%s-%d", key, ordinals[key])
+	}
+	return fmt.Sprintf("note-%s@%s", key, domain)
+}
+
+// uniqueUID appends a counter to UIDs already used in the same calendar
+func uniqueUID(uid string, seen map[string]int) string {
+	seen[uid]++
+	if seen[uid] == 1 {
+		return uid
+	}
+	local, host, _ := strings.Cut(uid, "@")
+	return fmt.Sprintf("%s-%d@%s", local, seen[uid], host)
+}
+
+// reviseEvent returns the revision for uid, bumping its sequence and
+// last-modified time when the event content differs from the previous run
+func reviseEvent(uid, content string, now time.Time) *EventRevision {
+	hash := shortHash(content)
+
+	eventRevisionsMu.Lock()
+	defer eventRevisionsMu.Unlock()
+
+	rev, ok := EventRevisions[uid]
+	if !eventRevisionsFrozenAt.IsZero() {
+		// Server mode: stamp as the next build would, leaving the saved revisions alone
+		switch {
+		case !ok:
+			return &EventRevision{Hash: hash, LastModified: eventRevisionsFrozenAt}
+		case rev.Hash != hash:
+			return &EventRevision{Hash: hash, Sequence: rev.Sequence + 1, LastModified: eventRevisionsFrozenAt}
+		}
+		return rev
+	}
+	if !ok {
+		rev = &EventRevision{Hash: hash, LastModified: now}
+		EventRevisions[uid] = rev
+	} else if rev.Hash != hash {
+		rev.Hash = hash
+		rev.Sequence++
+		rev.LastModified = now
+	}
+	return rev
+}
+
+// stateFilePath names a file kept between runs next to the output directory
+// rather than in it, so the web server never publishes it and cleaning the
+// output directory doesn't lose it (e.g. "dist" and "-event-revisions.json"
+// give ".dist-event-revisions.json")
+func stateFilePath(distDir, suffix string) string {
+	return filepath.Join(filepath.Dir(distDir), "."+filepath.Base(distDir)+suffix)
+}
+
+// moveLegacyState moves a file an earlier build kept inside the output
+// directory to where it lives now, unless something is already there
+func moveLegacyState(legacyPath, path string) {
+	if _, err := os.Stat(legacyPath); err != nil {
+		return
+	}
+	if _, err := os.Stat(path); err == nil {
+		os.Remove(legacyPath)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		fmt.Printf("Error moving %s: %v\n", legacyPath, err)
+		return
+	}
+	if err := os.Rename(legacyPath, path); err != nil {
+		fmt.Printf("Error moving %s: %v\n", legacyPath, err)
+	}
+}
+
+func loadEventRevisions(path string) (map[string]*EventRevision, error) {
+	revisions := make(map[string]*EventRevision)
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return revisions, nil
+	}
 	if err != nil {
-		return fmt.Errorf("error writing iCal file: %v", err)
+		return revisions, fmt.Errorf("error reading event revisions: %v", err)
+	}
+
+	if err := json.Unmarshal(data, &revisions); err != nil {
+		return make(map[string]*EventRevision), fmt.Errorf("error parsing event revisions: %v", err)
 	}
+	return revisions, nil
+}
 
+func saveEventRevisions(path string, revisions map[string]*EventRevision) error {
+	data, err := json.MarshalIndent(revisions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding event revisions: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing event revisions: %v", err)
+	}
 	return nil
 }
 
-func escapeICalText(text string) string {
-	text = strings.ReplaceAll(text, "\\", "\\\\")
-	text = strings.ReplaceAll(text, ",", "\\,")
-	text = strings.ReplaceAll(text, ";", "\\;")
-	text = strings.ReplaceAll(text, "\n", "\\n")
-	text = strings.ReplaceAll(text, "\r", "")
-	return text
+// slugify lowercases text and collapses anything that isn't a letter or
+// digit into single dashes (e.g., "Game #12" -> "game-12")
+func slugify(text string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// shortHash returns the first 12 hex characters of text's SHA-1
+func shortHash(text string) string {
+	sum := sha1.Sum([]byte(text))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// icalParamValue strips characters that can't appear in a parameter value
+func icalParamValue(text string) string {
+	text = strings.ReplaceAll(text, `"`, "'")
+	text = strings.ReplaceAll(text, "\n", " ")
+	return strings.ReplaceAll(text, "\r", "")
 }
 
 // parseMarkdownLinks converts markdown links [text](url) to "text: url" format
@@ -1300,16 +2246,77 @@ func parseMarkdownLinks(text string) string {
 }
 
 func main() {
+	serveAddr := flag.String("serve", "", "after generating, serve the output directory on this address (e.g. :8080) with on-demand /combo/ pages")
+	credentials := flag.String("credentials", "", "read a private sheet through the Sheets API with this service account key file")
+	sheetsAPI := flag.String("sheets-api", sheetsAPIBaseURL, "Sheets API base URL (e.g. a local stub server)")
+	workbook := flag.String("workbook", "", "read every tab from this local .xlsx or .ods file instead of the Google Sheet")
+	flag.StringVar(&mapProvider, "map-provider", mapProvider, `map links point at "google", "apple" or "osm"`)
+	flag.StringVar(&mergePrecedence, "merge-precedence", mergePrecedence, "comma-separated sources, first to last, whose copy of a duplicate game wins")
+	flag.Func("season-start-month", `month seasons start in, by name or number (default "January")`, func(value string) error {
+		month, err := parseMonth(value)
+		if err != nil {
+			return err
+		}
+		seasonStartMonth = month
+		return nil
+	})
+	flag.StringVar(&currentSeason, "season", currentSeason, `season shown on the main pages (e.g. "2025-26"); default is the one containing today`)
+	flag.Parse()
+
+	switch mapProvider {
+	case "google", "apple", "osm":
+	default:
+		fmt.Printf("Error: unknown -map-provider %q (use google, apple or osm)\n", mapProvider)
+		os.Exit(1)
+	}
+	if err := checkMergePrecedence(mergePrecedence); err != nil {
+		fmt.Printf("Error: -merge-precedence: %v\n", err)
+		os.Exit(1)
+	}
+
 	var allGames []Game
 
-	// Fetch teams from Google Sheet
+	// Load the home timezone (embedded tzdata means this only fails on a typo)
 	var err error
+	HomeLocation, err = time.LoadLocation(homeTimezone)
+	if err != nil {
+		fmt.Printf("Error loading timezone %s: %v\n", homeTimezone, err)
+		os.Exit(1)
+	}
+
+	// Read a local workbook or a private sheet through the Sheets API instead
+	// of the public export
+	switch {
+	case *workbook != "" && *credentials != "":
+		fmt.Println("Error: use either -workbook or -credentials, not both")
+		os.Exit(1)
+	case *workbook != "":
+		Sheet, err = openWorkbook(*workbook)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	case *credentials != "":
+		Sheet, err = newAPISheet(*credentials, *sheetsAPI)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Fetch teams from Google Sheet
 	AllTeams, err = fetchTeams()
 	if err != nil {
 		fmt.Printf("Error fetching teams: %v\n", err)
 		os.Exit(1)
 	}
 
+	// Fetch jersey sets from Google Sheet
+	err = fetchJerseys()
+	if err != nil {
+		fmt.Printf("Error fetching jerseys: %v\n", err)
+	}
+
 	// Fetch locations from Google Sheet
 	AllLocations, err = fetchLocations()
 	if err != nil {
@@ -1317,20 +2324,26 @@ func main() {
 		AllLocations = []Location{} // Use empty slice if fetch fails
 	}
 
-	// Fetch games from team URLs (skip teams without URLs)
-	for _, team := range AllTeams {
-		if team.CBLLink1 != "" {
-			games, err := scrapeTeamSchedule(team.Name, team.CBLLink1, team.CBLName, team.CssClass)
-			if err != nil {
-				fmt.Printf("Error: %v\n", err)
-			} else {
-				allGames = append(allGames, games...)
-			}
-		}
-		if team.CBLLink2 != "" {
-			games, err := scrapeTeamSchedule(team.Name, team.CBLLink2, team.CBLName, team.CssClass)
+	// Fetch opponent names first so other clubs' "Lightning" teams aren't
+	// mistaken for ours while scraping
+	OpponentAliases, err = fetchOpponents()
+	if err != nil {
+		fmt.Printf("Error fetching opponents: %v\n", err)
+	}
+
+	// Add the schedule links listed in the TeamSources tab
+	err = fetchTeamSources()
+	if err != nil {
+		fmt.Printf("Error fetching team sources: %v\n", err)
+	}
+
+	// Fetch games from each team's schedule links (skip teams without any)
+	for i := range AllTeams {
+		team := &AllTeams[i]
+		for _, source := range team.Sources {
+			games, err := scrapeSource(team, source)
 			if err != nil {
-				fmt.Printf("Error: %v\n", err)
+				addBuildWarning("%v", err)
 			} else {
 				allGames = append(allGames, games...)
 			}
@@ -1357,10 +2370,48 @@ func main() {
 		allNotes = []Note{} // Use empty slice if fetch fails
 	}
 
+	// Use one name per opponent no matter how each source spells it
+	normalizeOpponents(allGames)
+
+	// Games listed by more than one source are only kept once
+	allGames, merges := mergeDuplicateGames(allGames)
+
+	// Fix what sources get wrong (wrong gym, moved games) from the Overrides tab
+	overrides, err := fetchOverrides()
+	if err != nil {
+		fmt.Printf("Error fetching overrides: %v\n", err)
+	}
+	allGames = applyOverrides(allGames, overrides)
+
+	// Main pages show the current season; every season is kept in the archive
+	season := activeSeason(time.Now().In(HomeLocation))
+	assignSeasons(allGames, season)
+	everyGame := allGames
+	allGames = seasonGames(everyGame, season)
+	allNotes = seasonNotes(allNotes, season)
+
+	// Check coach and family groups for overlapping games and tight transitions
+	TravelTimes, err = fetchTravelTimes()
+	if err != nil {
+		fmt.Printf("Error fetching travel times: %v\n", err)
+	}
+	groups, err := fetchGroups()
+	if err != nil {
+		fmt.Printf("Error fetching groups: %v\n", err)
+	}
+	families, err := fetchFamilies()
+	if err != nil {
+		fmt.Printf("Error fetching families: %v\n", err)
+	}
+	for _, combo := range families {
+		groups = append(groups, Group{Name: combo.Title, Teams: combo.Teams})
+	}
+	conflicts := analyzeConflicts(allGames, groups)
+
 	// Get output directory from command line argument or use default "dist"
 	outputDir := "dist"
-	if len(os.Args) > 1 {
-		outputDir = os.Args[1]
+	if flag.NArg() > 0 {
+		outputDir = flag.Arg(0)
 	}
 
 	// Expand tilde if present
@@ -1385,15 +2436,52 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Load iCal event revisions from the previous run
+	revisionsPath := stateFilePath(distDir, eventRevisionsFile)
+	EventRevisions, err = loadEventRevisions(revisionsPath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	// Earlier builds kept revisions inside the output directory; carry them
+	// over once and stop publishing them
+	legacyRevisionsPath := filepath.Join(distDir, ".event-revisions.json")
+	if len(EventRevisions) == 0 {
+		if legacy, err := loadEventRevisions(legacyRevisionsPath); err == nil && len(legacy) > 0 {
+			EventRevisions = legacy
+		}
+	}
+	os.Remove(legacyRevisionsPath)
+
+	// Opponent history and archived seasons moved out of the output directory
+	// the same way
+	historyPath := stateFilePath(distDir, opponentHistoryFile)
+	seasonsDir := stateFilePath(distDir, seasonGamesDir)
+	moveLegacyState(filepath.Join(distDir, "opponents", "history.json"), historyPath)
+	if entries, err := os.ReadDir(filepath.Join(distDir, "archive")); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() {
+				moveLegacyState(filepath.Join(distDir, "archive", entry.Name(), "games.json"), filepath.Join(seasonsDir, entry.Name()+".json"))
+			}
+		}
+	}
+
+	// Update the head-to-head history first so schedules only link to
+	// opponents that get a page
+	meetings, err := updateOpponentHistory(everyGame, historyPath)
+	if err != nil {
+		fmt.Printf("Error updating opponent history: %v\n", err)
+	}
+
 	// Generate combined schedule as index.html in output directory
-	err = generateHTML(allGames, allNotes, filepath.Join(distDir, "index.html"), nil)
+	err = generateHTML(allGames, allNotes, filepath.Join(distDir, "index.html"), AllGamesFilter())
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
 	// Generate combined iCal file
-	err = generateICalendar(allGames, allNotes, filepath.Join(distDir, "schedule.ics"), nil)
+	err = generateICalendar(allGames, allNotes, filepath.Join(distDir, "schedule.ics"), AllGamesFilter())
 	if err != nil {
 		fmt.Printf("Error generating combined iCal: %v\n", err)
 	}
@@ -1408,17 +2496,130 @@ func main() {
 		}
 
 		// Generate HTML for team
-		err = generateHTML(allGames, allNotes, filepath.Join(teamDir, "index.html"), &team)
+		err = generateHTML(allGames, allNotes, filepath.Join(teamDir, "index.html"), TeamFilter(&team))
 		if err != nil {
 			fmt.Printf("Error generating HTML for %s: %v\n", team.Name, err)
 		}
 
 		// Generate iCal for team
-		err = generateICalendar(allGames, allNotes, filepath.Join(teamDir, "schedule.ics"), &team)
+		err = generateICalendar(allGames, allNotes, filepath.Join(teamDir, "schedule.ics"), TeamFilter(&team))
 		if err != nil {
 			fmt.Printf("Error generating iCal for %s: %v\n", team.Name, err)
 		}
 	}
 
-	fmt.Printf("💪 Generated schedule with %d games and %d notes\n", len(allGames), len(allNotes))
+	// Generate a page and calendar per venue, plus an index of all venues
+	locationsDir := filepath.Join(distDir, "locations")
+	for i := range AllLocations {
+		err = generateView(allGames, allNotes, distDir, LocationFilter(&AllLocations[i]))
+		if err != nil {
+			fmt.Printf("Error generating schedule for %s: %v\n", AllLocations[i].Name, err)
+		}
+	}
+
+	// Venue pages create the directory, but a club without locations has none
+	err = os.MkdirAll(locationsDir, 0755)
+	if err == nil {
+		err = generateLocationsIndex(allGames, filepath.Join(locationsDir, "index.html"))
+	}
+	if err != nil {
+		fmt.Printf("Error generating locations index: %v\n", err)
+	}
+
+	// Generate club-wide standings
+	standingsDir := filepath.Join(distDir, "standings")
+	err = os.MkdirAll(standingsDir, 0755)
+	if err == nil {
+		err = generateStandings(allGames, standingsDir)
+	}
+	if err != nil {
+		fmt.Printf("Error generating standings: %v\n", err)
+	}
+
+	// Generate the opponent directory with head-to-head history across seasons
+	opponentsDir := filepath.Join(distDir, "opponents")
+	err = os.MkdirAll(opponentsDir, 0755)
+	if err == nil {
+		err = generateOpponents(meetings, opponentsDir)
+	}
+	if err != nil {
+		fmt.Printf("Error generating opponents: %v\n", err)
+	}
+
+	// Archive every season's games and generate pages for past seasons
+	archiveDir := filepath.Join(distDir, "archive")
+	err = os.MkdirAll(archiveDir, 0755)
+	if err == nil {
+		err = generateArchive(everyGame, season, seasonsDir, archiveDir)
+	}
+	if err != nil {
+		fmt.Printf("Error generating archive: %v\n", err)
+	}
+
+	// Generate the weekend and today summaries using the home timezone's calendar
+	localNow := time.Now().In(HomeLocation)
+	for _, view := range []Filter{WeekendFilter(localNow), TodayFilter(localNow)} {
+		err = generateView(allGames, allNotes, distDir, view)
+		if err != nil {
+			fmt.Printf("Error generating %s: %v\n", view.Title, err)
+		}
+	}
+
+	// Generate the extra views configured in the Views tab
+	views, err := fetchViews()
+	if err != nil {
+		fmt.Printf("Error fetching views: %v\n", err)
+	}
+	for _, view := range views {
+		err = generateView(allGames, allNotes, distDir, view)
+		if err != nil {
+			fmt.Printf("Error generating view %s: %v\n", view.Title, err)
+		}
+	}
+
+	err = writeConflictsReport(conflicts, filepath.Join(distDir, "conflicts.txt"))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	err = writeMergeReport(merges, filepath.Join(distDir, "merges.txt"))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	// Generate the family combinations listed in the Families tab
+	for _, combo := range families {
+		err = generateView(allGames, allNotes, distDir, combo)
+		if err != nil {
+			fmt.Printf("Error generating combination %s: %v\n", combo.Title, err)
+		}
+	}
+
+	err = saveEventRevisions(revisionsPath, EventRevisions)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+
+	fmt.Printf("💪 Generated %s schedule with %d games and %d notes\n", season, len(allGames), len(allNotes))
+	if len(conflicts) > 0 {
+		fmt.Printf("⚠️  Found %d schedule conflicts (see conflicts.txt)\n", len(conflicts))
+	}
+	if len(merges) > 0 {
+		fmt.Printf("🔀 Merged %d duplicate games (see merges.txt)\n", len(merges))
+	}
+	if len(BuildWarnings) > 0 {
+		fmt.Printf("⚠️  %d build warnings:\n", len(BuildWarnings))
+		for _, warning := range BuildWarnings {
+			fmt.Printf("   - %s\n", warning)
+		}
+	}
+
+	if *serveAddr != "" {
+		eventRevisionsFrozenAt = time.Now()
+		err = serve(*serveAddr, allGames, allNotes, distDir)
+		if err != nil {
+			fmt.Printf("Error serving: %v\n", err)
+			os.Exit(1)
+		}
+	}
 }
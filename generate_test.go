@@ -0,0 +1,340 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"lightning/schedule/internal/ical"
+)
+
+func TestRenderICalendarValidates(t *testing.T) {
+	setupTestClub(t)
+	EventRevisions = map[string]*EventRevision{}
+	gold, blue := &AllTeams[0], &AllTeams[1]
+	ralston, denver := &AllLocations[0], &AllLocations[1]
+
+	games := []Game{
+		{Team: gold, Date: "Saturday, October 18, 2025", Time: "9:00 AM", Location: ralston, CourtGymInfo: "Court 2",
+			Opponent: "Omaha Sky", HomeAway: "Home", Tournament: "Fall Classic", Division: "12U Girls", Round: "Pool A", SourceID: "tm-h1-12"},
+		{Team: gold, Date: "Sunday, October 19, 2025", Time: "TBD", Opponent: "Winner of Game 12",
+			Condition: "If 12U Gold wins Game 12", Tournament: "Fall Classic", SourceID: "tm-h1-18"},
+		{Team: blue, Date: "Saturday, October 25, 2025", Time: "10:00 AM", Location: denver, Opponent: "Denver Heat; Elite, 10U",
+			HomeAway: "Away", Score: "W 41-38", Result: "W", Origin: "sheet"},
+	}
+	notes := []Note{
+		{Date: "Saturday, October 18, 2025", Text: "Team photos before the game | Bring both jerseys, please", Teams: "All Teams"},
+	}
+
+	for _, filter := range []Filter{AllGamesFilter(), TeamFilter(gold), ComboFilter([]*Team{gold, blue})} {
+		output, err := renderICalendar(games, notes, filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ical.Validate(output); err != nil {
+			t.Errorf("%s feed doesn't validate: %v\n%s", filter.Title, err, output)
+		}
+	}
+}
+
+func TestLocationMapURL(t *testing.T) {
+	defer func(provider string) { mapProvider = provider }(mapProvider)
+
+	arena := Location{Name: "Ralston Arena", Address: "7300 Q Street, Ralston, NE 68127"}
+	field := Location{Name: "Field", Latitude: 41.2, Longitude: -96.04, HasGeo: true}
+
+	tests := []struct {
+		provider string
+		location Location
+		want     string
+	}{
+		{"google", arena, "https://www.google.com/maps/search/?api=1&query=7300+Q+Street%2C+Ralston%2C+NE+68127"},
+		{"google", field, "https://www.google.com/maps/search/?api=1&query=41.2%2C-96.04"},
+		{"apple", arena, "https://maps.apple.com/?address=7300+Q+Street%2C+Ralston%2C+NE+68127"},
+		{"apple", field, "https://maps.apple.com/?ll=41.2%2C-96.04&q=Field"},
+		{"osm", arena, "https://www.openstreetmap.org/search?query=7300+Q+Street%2C+Ralston%2C+NE+68127"},
+		{"osm", field, "https://www.openstreetmap.org/?mlat=41.2&mlon=-96.04#map=17/41.2/-96.04"},
+		{"osm", Location{Name: "Somewhere"}, ""},
+	}
+	for _, test := range tests {
+		mapProvider = test.provider
+		if got := test.location.MapURL(); got != test.want {
+			t.Errorf("%s MapURL(%s) = %q, want %q", test.provider, test.location.Name, got, test.want)
+		}
+	}
+}
+
+func TestStateFilesLiveNextToOutput(t *testing.T) {
+	root := t.TempDir()
+	distDir := filepath.Join(root, "dist")
+
+	historyPath := stateFilePath(distDir, opponentHistoryFile)
+	if want := filepath.Join(root, ".dist-opponent-history.json"); historyPath != want {
+		t.Errorf("stateFilePath = %s, want %s", historyPath, want)
+	}
+
+	// An earlier build's file moves out of the output directory
+	legacy := filepath.Join(distDir, "opponents", "history.json")
+	if err := os.MkdirAll(filepath.Dir(legacy), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacy, []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	moveLegacyState(legacy, historyPath)
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Error("legacy file is still in the output directory")
+	}
+	if data, err := os.ReadFile(historyPath); err != nil || string(data) != "[]" {
+		t.Errorf("moved file = %q, %v", data, err)
+	}
+
+	// A newer file isn't replaced by a stale one
+	os.WriteFile(legacy, []byte("stale"), 0644)
+	moveLegacyState(legacy, historyPath)
+	if data, _ := os.ReadFile(historyPath); string(data) != "[]" {
+		t.Errorf("stale legacy file replaced the current one: %q", data)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Error("stale legacy file is still in the output directory")
+	}
+}
+
+func TestSheetWarningsReachTheBuildSummary(t *testing.T) {
+	setupTestClub(t)
+	Sheet = fakeSheet{
+		locationsTab: {
+			{"Abbrev", "Name", "Latitude", "Longitude", "Timezone"},
+			{"RA", "Ralston Arena", "41.2", "-96.04", ""},
+			{"WS", "Westside", "41.2", "", ""},
+			{"MHF", "Mile High Fieldhouse", "", "", "America/Denvr"},
+		},
+		"Groups": {
+			{"Name", "Teams"},
+			{"Coach Kim", "12U Gold, 11U Red, 10U Blue"},
+		},
+	}
+	defer func(sheet SheetBackend) { Sheet = sheet }(Sheet)
+
+	locations, err := fetchLocations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locations) != 3 || !locations[0].HasGeo || locations[1].HasGeo || locations[2].Timezone != nil {
+		t.Errorf("locations = %+v", locations)
+	}
+	if _, err := fetchGroups(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`ignoring invalid coordinates "41.2", "" for Westside`,
+		`unknown timezone "America/Denvr" for Mile High Fieldhouse`,
+		`unknown team "11U Red" in group Coach Kim`,
+	}
+	if len(BuildWarnings) != len(want) {
+		t.Fatalf("warnings = %q, want %d", BuildWarnings, len(want))
+	}
+	for i, warning := range want {
+		if !strings.HasPrefix(BuildWarnings[i], warning) {
+			t.Errorf("warning %d = %q, want %q", i, BuildWarnings[i], warning)
+		}
+	}
+}
+
+func TestBracketPath(t *testing.T) {
+	setupTestClub(t)
+	team := &AllTeams[0]
+	us := "Lightning 12U Gold"
+
+	rows := []tourneyMachineRow{
+		// Pool play, not yet final
+		{GameNum: "12", Visitor: "Storm", Home: us},
+		// Semifinal: winner of 12 against winner of 13 (us nowhere)
+		{GameNum: "13", Visitor: "Hawks", Home: "Sky"},
+		{GameNum: "20", Visitor: "Winner of Game 12", Home: "Winner of Game 13"},
+		// Consolation for the loser of 20, final for the winner
+		{GameNum: "24", Visitor: "Loser of Game 20", Home: "Heat"},
+		{GameNum: "25", Visitor: "Winner #20", Home: "Winner of Game 21"},
+		// Decided games: we won 14 and lost 15
+		{GameNum: "14", Visitor: us, VisitorScore: "40", HomeScore: "31", Home: "Storm"},
+		{GameNum: "15", Visitor: "Sky", VisitorScore: "35", HomeScore: "30", Home: us},
+		// A placeholder loop in a broken bracket
+		{GameNum: "30", Visitor: "Winner of Game 31", Home: "Heat"},
+		{GameNum: "31", Visitor: "Winner of Game 30", Home: "Hawks"},
+	}
+	rowsByNum := make(map[string]*tourneyMachineRow)
+	for i := range rows {
+		rowsByNum[rows[i].GameNum] = &rows[i]
+	}
+
+	tests := []struct {
+		participant string
+		want        []string
+		wantOK      bool
+	}{
+		{us, nil, true},
+		{"lightning  12u GOLD", nil, true},
+		{"Storm", nil, false},
+		{"Winner of Game 12", []string{"wins Game 12"}, true},
+		{"Loser of Game 12", []string{"loses Game 12"}, true},
+		{"Winner of Game 13", nil, false},
+		// Chained placeholders list every step, earliest first
+		{"Loser of Game 20", []string{"wins Game 12", "loses Game 20"}, true},
+		{"Winner #20", []string{"wins Game 12", "wins Game 20"}, true},
+		// Decided results: a win puts us in, a loss knocks us out
+		{"Winner of Game 14", nil, true},
+		{"Loser of Game 14", nil, false},
+		{"Winner of Game 15", nil, false},
+		{"Loser of Game 15", nil, true},
+		// Unknown games and loops end without a match
+		{"Winner of Game 99", nil, false},
+		{"Winner of Game 30", nil, false},
+	}
+	for _, test := range tests {
+		path, ok := bracketPath(test.participant, team, rowsByNum, 0)
+		if ok != test.wantOK || strings.Join(path, "|") != strings.Join(test.want, "|") {
+			t.Errorf("bracketPath(%q) = %q, %v; want %q, %v", test.participant, path, ok, test.want, test.wantOK)
+		}
+	}
+}
+
+func TestScrapeTourneyMachineSchedule(t *testing.T) {
+	setupTestClub(t)
+	srv := fixtureServer(t)
+	team := &AllTeams[0]
+
+	games, err := scrapeTeamSchedule(team, srv.URL+"/Public/Results/Team.aspx?IDTournament=h2025&fixture=tourneymachine-team.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkGames(t, games, []wantGame{
+		// Game 12 is final and we won it, so "Winner of Game 12" is us
+		{"Sunday, October 19, 2025", "1:00 PM", "Hawks", "Away", "", "Mile High Fieldhouse", "", "tm-h2025-20"},
+		// Game 20 isn't played yet
+		{"Sunday, October 19, 2025", "5:00 PM", "Winner of Game 23", "Away", "", "Mile High Fieldhouse", "", "tm-h2025-22"},
+		{"Saturday, October 18, 2025", "9:00 AM", "Storm", "Home", "W 40-31", "Ralston Arena", "Court 2", "tm-h2025-12"},
+		{"Saturday, October 18, 2025", "11:00 AM", "Sky", "Away", "L 30-35", "Ralston Arena", "Court 2", "tm-h2025-14"},
+		{"Sunday, October 26, 2025", "6:00 PM", "Storm", "Away", "", "Ralston Arena", "", "tm-h2025-40"},
+	})
+
+	details := []struct{ round, condition string }{
+		{"Gold Bracket - Semifinals", ""},
+		{"Gold Bracket - Semifinals", "If 12U Gold wins Game #20"},
+		{"Pool A", ""},
+		{"Pool A", ""},
+		{"Pool A", ""},
+	}
+	for i, want := range details {
+		game := games[i]
+		if game.Tournament != "Fall Classic" || game.Division != "12U Girls" || game.Round != want.round || game.Condition != want.condition {
+			t.Errorf("game %d: tournament %q, division %q, round %q, condition %q; want round %q, condition %q",
+				i, game.Tournament, game.Division, game.Round, game.Condition, want.round, want.condition)
+		}
+	}
+	if len(BuildWarnings) != 0 {
+		t.Errorf("unexpected warnings %q", BuildWarnings)
+	}
+}
+
+func TestScrapeTourneyMachineScheduleErrors(t *testing.T) {
+	setupTestClub(t)
+	srv := fixtureServer(t)
+
+	tests := []struct {
+		team    *Team
+		fixture string
+		want    string
+	}{
+		{&AllTeams[0], "tourneymachine-empty.html", "found no games"},
+		{&AllTeams[1], "tourneymachine-team.html", "found 7 games on 10U Blue page but none for the team"},
+		{&AllTeams[0], "missing.html", "status code 404"},
+	}
+	for _, test := range tests {
+		_, err := scrapeTeamSchedule(test.team, srv.URL+"/Public/Results/Team.aspx?IDTournament=h2025&fixture="+test.fixture)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s for %s: error %v, want %q", test.fixture, test.team.Name, err, test.want)
+		}
+	}
+}
+
+func TestScheduleColumnsFromHeader(t *testing.T) {
+	tests := []struct {
+		header string
+		want   scheduleColumns
+		ok     bool
+	}{
+		{`<th>Game</th><th>Time</th><th>Location</th><th>Visitor</th><th>Score</th><th>Score</th><th>Home</th>`,
+			scheduleColumns{Game: 0, Time: 1, Location: 2, Visitor: 3, VisitorScore: 4, HomeScore: 5, Home: 6}, true},
+		{`<th>#</th><th>Date/Time</th><th>Home</th><th>Home Score</th><th>Away Score</th><th>Away</th><th>Court</th>`,
+			scheduleColumns{Game: 0, Time: 1, Location: 6, Visitor: 5, VisitorScore: 4, HomeScore: 3, Home: 2}, true},
+		// Colspans shift the columns after them; unlabeled neighbors hold the scores
+		{`<th colspan="2"></th><th>Game #</th><th>Date &amp; Time</th><th>Venue</th><th>Visitor</th><th></th><th></th><th>Home</th>`,
+			scheduleColumns{Game: 2, Time: 3, Location: 4, Visitor: 5, VisitorScore: 6, HomeScore: 7, Home: 8}, true},
+		// No score columns at all
+		{`<th>Game</th><th>Time</th><th>Visitor</th><th>Home</th>`,
+			scheduleColumns{Game: 0, Time: 1, Location: -1, Visitor: 2, VisitorScore: -1, HomeScore: -1, Home: 3}, true},
+		// Not a schedule header
+		{`<th colspan="7">Saturday, October 18, 2025</th>`, scheduleColumns{}, false},
+		{`<th>Team</th><th>W</th><th>L</th>`, scheduleColumns{}, false},
+	}
+	for _, test := range tests {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader("<table><tr>" + test.header + "</tr></table>"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, ok := scheduleColumnsFromHeader(doc.Find("tr").First())
+		if ok != test.ok || (ok && got != test.want) {
+			t.Errorf("scheduleColumnsFromHeader(%s) = %+v, %v; want %+v, %v", test.header, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestWeekendRange(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := func(month time.Month, d, year int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		from, to time.Time
+	}{
+		{"Monday", time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC), day(time.October, 16, 2026), day(time.October, 18, 2026)},
+		{"Tuesday", time.Date(2026, time.October, 13, 9, 0, 0, 0, time.UTC), day(time.October, 16, 2026), day(time.October, 18, 2026)},
+		{"Wednesday", time.Date(2026, time.October, 14, 9, 0, 0, 0, time.UTC), day(time.October, 16, 2026), day(time.October, 18, 2026)},
+		{"Thursday night", time.Date(2026, time.October, 15, 23, 59, 0, 0, time.UTC), day(time.October, 16, 2026), day(time.October, 18, 2026)},
+		// The weekend in progress, not the next one
+		{"Friday morning", time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC), day(time.October, 16, 2026), day(time.October, 18, 2026)},
+		{"Friday night", time.Date(2026, time.October, 16, 23, 59, 0, 0, time.UTC), day(time.October, 16, 2026), day(time.October, 18, 2026)},
+		{"Saturday", time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC), day(time.October, 16, 2026), day(time.October, 18, 2026)},
+		{"Sunday morning", time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC), day(time.October, 16, 2026), day(time.October, 18, 2026)},
+		{"Sunday night", time.Date(2026, time.October, 18, 23, 59, 0, 0, time.UTC), day(time.October, 16, 2026), day(time.October, 18, 2026)},
+		{"Monday after", time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC), day(time.October, 23, 2026), day(time.October, 25, 2026)},
+		// Weekends spanning a month or year
+		{"Wednesday before a month end", time.Date(2026, time.October, 28, 9, 0, 0, 0, time.UTC), day(time.October, 30, 2026), day(time.November, 1, 2026)},
+		{"Sunday in the next month", time.Date(2026, time.November, 1, 9, 0, 0, 0, time.UTC), day(time.October, 30, 2026), day(time.November, 1, 2026)},
+		{"New Year's Eve", time.Date(2026, time.December, 31, 9, 0, 0, 0, time.UTC), day(time.January, 1, 2027), day(time.January, 3, 2027)},
+		{"Saturday in the new year", time.Date(2027, time.January, 2, 9, 0, 0, 0, time.UTC), day(time.January, 1, 2027), day(time.January, 3, 2027)},
+		// The local day counts, though it's already Monday in UTC
+		{"Sunday night in Chicago", time.Date(2026, time.October, 18, 22, 0, 0, 0, chicago), day(time.October, 16, 2026), day(time.October, 18, 2026)},
+	}
+	for _, test := range tests {
+		from, to := weekendRange(test.now)
+		if !from.Equal(test.from) || !to.Equal(test.to) {
+			t.Errorf("%s: weekendRange(%s) = %s to %s, want %s to %s", test.name, test.now.Format(time.RFC1123), from.Format("Mon Jan 2"), to.Format("Mon Jan 2"), test.from.Format("Mon Jan 2"), test.to.Format("Mon Jan 2"))
+		}
+		if from.Weekday() != time.Friday || to.Weekday() != time.Sunday {
+			t.Errorf("%s: weekendRange(%s) = %s to %s, want Friday to Sunday", test.name, test.now.Format(time.RFC1123), from.Format("Mon Jan 2"), to.Format("Mon Jan 2"))
+		}
+	}
+}
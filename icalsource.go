@@ -0,0 +1,280 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"lightning/schedule/internal/ical"
+)
+
+// Separators between the two teams in a calendar event summary
+var matchupSeparatorRegex = regexp.MustCompile(`(?i)\s+(vs\.?|v\.?|versus|@|at)\s+`)
+
+// Matches a trailing "(...)" in a summary, e.g. "(Home)" or "(Pool A)"
+var summarySuffixRegex = regexp.MustCompile(`\s*\(([^)]*)\)\s*$`)
+
+// Matches a leading label in a summary, e.g. "Game:", "Game 12 -" or "[12U Gold]"
+var summaryPrefixRegex = regexp.MustCompile(`(?i)^\s*(\[[^\]]*\]|game\s*#?\d*\s*[:\-]|basketball\s*[:\-])\s*`)
+
+// Matches "Home/Away: Away" style lines in an event description
+var descriptionHomeAwayRegex = regexp.MustCompile(`(?im)^\s*(?:home\s*/\s*away|jersey|side)\s*:\s*(home|away)\b`)
+
+// Matches the part of a location that names a court or gym (e.g., "Court 2")
+var courtInfoRegex = regexp.MustCompile(`(?i)\b(court|gym)\b`)
+
+// fetchICalGames reads a team's games from another organization's calendar
+// feed. The URL may be http(s), webcal, or a local .ics file (a path or a
+// file:// URL), which keeps feeds easy to try out offline.
+func fetchICalGames(team *Team, url string) ([]Game, error) {
+	body, err := openICalSource(url)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s calendar: %v", team.Name, err)
+	}
+	defer body.Close()
+
+	cal, err := ical.Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s calendar: %v", team.Name, err)
+	}
+
+	var games []Game
+	events := 0
+	for _, event := range cal.Children {
+		if event.Name != "VEVENT" {
+			continue
+		}
+		events++
+		game, ok := icalEventGame(team, event)
+		if ok {
+			games = append(games, game)
+		}
+	}
+
+	if len(games) == 0 {
+		return nil, fmt.Errorf("found %d events in %s calendar but no games for the team (%s)", events, team.Name, url)
+	}
+
+	return games, nil
+}
+
+// openICalSource opens a calendar feed from the web or the local disk
+func openICalSource(url string) (io.ReadCloser, error) {
+	lower := strings.ToLower(url)
+	switch {
+	case strings.HasPrefix(lower, "file://"):
+		return os.Open(url[len("file://"):])
+	case !strings.Contains(url, "://"):
+		return os.Open(url)
+	case strings.HasPrefix(lower, "webcal://"):
+		url = "https://" + url[len("webcal://"):]
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("received status code %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// icalEventGame turns a calendar event into a game for the team, or reports
+// false for events that aren't one of its games (practices, other teams)
+func icalEventGame(team *Team, event *ical.Component) (Game, bool) {
+	dtstart := event.Get("DTSTART")
+	if dtstart == nil || strings.EqualFold(icalText(event, "STATUS"), "CANCELLED") {
+		return Game{}, false
+	}
+
+	matchup, ok := parseMatchup(team, icalText(event, "SUMMARY"))
+	if !ok {
+		return Game{}, false
+	}
+	if match := descriptionHomeAwayRegex.FindStringSubmatch(icalText(event, "DESCRIPTION")); match != nil && matchup.HomeAway == "" {
+		matchup.HomeAway = strings.ToUpper(match[1][:1]) + strings.ToLower(match[1][1:])
+	}
+
+	// Times are shown as wall-clock times at the venue, so find it first
+	loc, courtGymInfo := matchLocation(icalText(event, "LOCATION"))
+	date, timeStr := icalEventStart(dtstart, Game{Location: loc}.Zone())
+	if date.IsZero() {
+		return Game{}, false
+	}
+
+	return Game{
+		Team:         team,
+		Date:         date.Format("Monday, January 2, 2006"),
+		Time:         timeStr,
+		Location:     loc,
+		CourtGymInfo: courtGymInfo,
+		Opponent:     matchup.Opponent,
+		HomeAway:     matchup.HomeAway,
+		Round:        matchup.Round,
+		SourceID:     "ics-" + shortHash(icalEventID(event)),
+	}, true
+}
+
+// matchup is what an event summary tells us about a game
+type matchup struct {
+	Opponent string
+	HomeAway string // "Home", "Away" or "" when the summary doesn't say
+	Round    string // e.g. "Pool A" from "(Pool A)"
+}
+
+// parseMatchup reads the opponent and home/away from an event summary.
+// It understands summaries such as:
+//
+//	"Lightning 12U Gold vs Sky"     (home)
+//	"Sky @ Lightning 12U Gold"      (home)
+//	"Lightning 12U Gold at Sky"     (away)
+//	"vs. Sky (Away)"                (a feed listing only our games)
+//	"Game 12: Storm v Lightning 12U Gold (Pool A)"
+//
+// "vs" doesn't settle home/away on its own: the first team named is taken
+// as home, which is what most league feeds do.
+func parseMatchup(team *Team, summary string) (matchup, bool) {
+	var result matchup
+
+	// Peel off "(Home)", "(Pool A)" and similar trailing notes
+	for {
+		match := summarySuffixRegex.FindStringSubmatchIndex(summary)
+		if match == nil {
+			break
+		}
+		note := strings.TrimSpace(summary[match[2]:match[3]])
+		switch {
+		case strings.EqualFold(note, "home"), strings.EqualFold(note, "away"):
+			result.HomeAway = strings.ToUpper(note[:1]) + strings.ToLower(note[1:])
+		case tournamentRoundRegex.MatchString(note):
+			result.Round = note
+		}
+		summary = summary[:match[0]]
+	}
+	summary = " " + summaryPrefixRegex.ReplaceAllString(summary, "") + " "
+
+	sep := matchupSeparatorRegex.FindStringSubmatchIndex(summary)
+	if sep == nil {
+		return result, false
+	}
+	first := strings.TrimSpace(summary[:sep[0]])
+	second := strings.TrimSpace(summary[sep[1]:])
+	word := strings.ToLower(summary[sep[2]:sep[3]])
+	visiting := word == "@" || word == "at"
+
+	isUs := func(side string) bool {
+		return side == "" || team.MatchesName(side) || nameKey(side) == nameKey(team.Name) || nameKey(side) == clubKeyword
+	}
+
+	var weAreFirst bool
+	switch {
+	case isUs(first) && !isUs(second):
+		result.Opponent, weAreFirst = second, true
+	case isUs(second) && !isUs(first):
+		result.Opponent, weAreFirst = first, false
+	default:
+		return result, false
+	}
+
+	// "A @ B" and "A at B" put B at home; "A vs B" is read as A at home.
+	// A feed of our own games ("vs Sky", "@ Sky") names only the opponent.
+	if result.HomeAway == "" {
+		result.HomeAway = "Home"
+		if weAreFirst == visiting {
+			result.HomeAway = "Away"
+		}
+	}
+	return result, true
+}
+
+// matchLocation finds a venue from free-form location text such as
+// "Ralston Arena - Court 2", "Ralston Arena, 7300 Q St, Ralston, NE" or just
+// an address, matching venue names, abbreviations and street addresses
+func matchLocation(text string) (*Location, string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ""
+	}
+
+	if loc, court := findLocationByName(text); loc != nil {
+		return loc, court
+	}
+
+	court := ""
+	for _, part := range strings.Split(text, ",") {
+		part = strings.TrimSpace(part)
+		if loc, partCourt := findLocationByName(part); loc != nil {
+			return loc, partCourt
+		}
+		if loc, partCourt := findLocationByAbbrev(part); loc != nil {
+			return loc, partCourt
+		}
+		for i := range AllLocations {
+			if strings.EqualFold(AllLocations[i].Name, part) {
+				return &AllLocations[i], ""
+			}
+		}
+		if courtInfoRegex.MatchString(part) {
+			court = part
+		}
+	}
+
+	return findLocationByAddress(text), court
+}
+
+// icalEventStart returns the event's date and time at the venue, whose
+// timezone is zone ("TBD" for all-day events). UTC and TZID times are
+// converted to it; floating times are already venue times.
+func icalEventStart(dtstart *ical.Property, zone *time.Location) (time.Time, string) {
+	value := dtstart.Value
+	if dtstart.Param("VALUE") == "DATE" || len(value) == 8 {
+		date, err := time.Parse("20060102", value)
+		if err != nil {
+			return time.Time{}, ""
+		}
+		return date, "TBD"
+	}
+
+	loc := zone
+	if tzid := dtstart.Param("TZID"); tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			loc = tz
+		}
+	}
+	var start time.Time
+	var err error
+	if strings.HasSuffix(value, "Z") {
+		start, err = time.Parse("20060102T150405Z", value)
+	} else {
+		start, err = time.ParseInLocation("20060102T150405", value, loc)
+	}
+	if err != nil {
+		return time.Time{}, ""
+	}
+	start = start.In(zone)
+	return start, start.Format("3:04 PM")
+}
+
+// icalEventID identifies an event across feed updates: its UID, or failing
+// that its start and summary
+func icalEventID(event *ical.Component) string {
+	if uid := icalText(event, "UID"); uid != "" {
+		return uid
+	}
+	return event.Get("DTSTART").Value + "|" + icalText(event, "SUMMARY")
+}
+
+// icalText returns the unescaped value of a TEXT property, or "" if missing
+func icalText(event *ical.Component, name string) string {
+	if prop := event.Get(name); prop != nil {
+		return strings.TrimSpace(prop.Text())
+	}
+	return ""
+}
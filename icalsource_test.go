@@ -0,0 +1,131 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// setupTestClub loads a small club (two teams, a home venue and an
+// out-of-town one) into the globals the scrapers read
+func setupTestClub(t *testing.T) {
+	t.Helper()
+
+	var err error
+	HomeLocation, err = time.LoadLocation(homeTimezone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	AllTeams = []Team{
+		{Name: "12U Gold", Slug: "12u-gold", CBLName: "Lightning 12U Gold", CBLAliases: []string{"Omaha Lightning 12U Gold"}},
+		{Name: "10U Blue", Slug: "10u-blue", CBLName: "Lightning 10U Blue"},
+	}
+	AllLocations = []Location{
+		{Abbrev: "RA", Name: "Ralston Arena", Address: "7300 Q Street, Ralston, NE 68127"},
+		{Abbrev: "MHF", Name: "Mile High Fieldhouse", Address: "1 Fieldhouse Way, Denver, CO 80202", Timezone: denver},
+	}
+	OpponentAliases = map[string]string{}
+	BuildWarnings = nil
+}
+
+func TestFetchICalGamesFromLocalFile(t *testing.T) {
+	setupTestClub(t)
+	team := &AllTeams[0]
+
+	games, err := fetchICalGames(team, "testdata/league-feed.ics")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		date, time, opponent, homeAway, location, court, round string
+	}{
+		// TZID time at a home venue
+		{"Saturday, October 18, 2025", "9:00 AM", "Omaha Sky", "Home", "Ralston Arena", "Court 2", ""},
+		// UTC time, venue found from its address, "@" puts us at home
+		{"Saturday, October 18, 2025", "3:00 PM", "Storm", "Home", "Ralston Arena", "", "Pool A"},
+		// UTC time shown in the venue's own timezone, not the home one
+		{"Saturday, October 25, 2025", "10:00 AM", "Denver Heat", "Away", "Mile High Fieldhouse", "", ""},
+		// All-day event in a feed of our own games
+		{"Saturday, November 1, 2025", "TBD", "Hawks", "Away", "", "", ""},
+	}
+	if len(games) != len(tests) {
+		for _, game := range games {
+			t.Logf("got %s %s vs %s", game.Date, game.Time, game.Opponent)
+		}
+		t.Fatalf("got %d games, want %d (practices, cancelled and other teams' games skipped)", len(games), len(tests))
+	}
+
+	for i, want := range tests {
+		game := games[i]
+		location := ""
+		if game.Location != nil {
+			location = game.Location.Name
+		}
+		got := []string{game.Date, game.Time, game.Opponent, game.HomeAway, location, game.CourtGymInfo, game.Round}
+		wanted := []string{want.date, want.time, want.opponent, want.homeAway, want.location, want.court, want.round}
+		for j := range got {
+			if got[j] != wanted[j] {
+				t.Errorf("game %d: got %q, want %q", i, got, wanted)
+				break
+			}
+		}
+		if game.SourceID == "" || game.SourceID[:4] != "ics-" {
+			t.Errorf("game %d: SourceID %q should start with ics-", i, game.SourceID)
+		}
+	}
+
+	// The venue's zone must round-trip: Start reads the text back at the venue
+	start, ok := games[2].Start()
+	if !ok || !start.Equal(time.Date(2025, 10, 25, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("Start() = %v, %v; want 16:00 UTC", start, ok)
+	}
+}
+
+func TestFetchICalGamesWithoutTeamGames(t *testing.T) {
+	setupTestClub(t)
+	team := &AllTeams[0]
+
+	if _, err := fetchICalGames(team, "file://testdata/practices.ics"); err == nil {
+		t.Error("expected an error for a feed with no games for the team")
+	}
+	if _, err := fetchICalGames(team, "testdata/missing.ics"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestParseMatchup(t *testing.T) {
+	setupTestClub(t)
+	team := &AllTeams[0]
+
+	tests := []struct {
+		summary  string
+		want     matchup
+		wantOurs bool
+	}{
+		{"Lightning 12U Gold vs Sky", matchup{Opponent: "Sky", HomeAway: "Home"}, true},
+		{"Lightning 12U Gold vs. Sky", matchup{Opponent: "Sky", HomeAway: "Home"}, true},
+		{"Sky vs Lightning 12U Gold", matchup{Opponent: "Sky", HomeAway: "Away"}, true},
+		{"Sky @ Lightning 12U Gold", matchup{Opponent: "Sky", HomeAway: "Home"}, true},
+		{"Lightning 12U Gold at Sky", matchup{Opponent: "Sky", HomeAway: "Away"}, true},
+		{"Omaha Lightning 12U Gold v Storm", matchup{Opponent: "Storm", HomeAway: "Home"}, true},
+		{"vs Hawks", matchup{Opponent: "Hawks", HomeAway: "Home"}, true},
+		{"@ Hawks", matchup{Opponent: "Hawks", HomeAway: "Away"}, true},
+		{"vs. Sky (Away)", matchup{Opponent: "Sky", HomeAway: "Away"}, true},
+		{"Game 12: Storm v Lightning 12U Gold (Pool A)", matchup{Opponent: "Storm", HomeAway: "Away", Round: "Pool A"}, true},
+		{"[12U Gold] Lightning vs Storm (Home)", matchup{Opponent: "Storm", HomeAway: "Home"}, true},
+		{"Lightning 12U Gold Practice", matchup{}, false},
+		{"Lightning 10U Blue vs Storm", matchup{}, false},
+		{"Sky vs Storm", matchup{}, false},
+	}
+	for _, test := range tests {
+		got, ok := parseMatchup(team, test.summary)
+		if ok != test.wantOurs || (ok && got != test.want) {
+			t.Errorf("parseMatchup(%q) = %+v, %v; want %+v, %v", test.summary, got, ok, test.want, test.wantOurs)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+// Package ical writes and reads RFC 5545 iCalendar data.
+//
+// Calendars are built as a tree of Components holding Properties. Writing a
+// component takes care of text escaping, CRLF line endings and folding lines
+// at 75 octets, so callers only deal with plain values.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Maximum length of a content line in octets, excluding the CRLF
+const maxLineOctets = 75
+
+// Property is a single content line such as "DTSTART;TZID=America/Chicago:20251018T100000"
+type Property struct {
+	Name   string
+	Params []Param
+	Value  string // Already escaped/encoded for its value type
+}
+
+// Param is a property parameter such as TZID=America/Chicago
+type Param struct {
+	Name  string
+	Value string
+}
+
+// Component is a BEGIN/END block such as VCALENDAR, VEVENT or VTIMEZONE
+type Component struct {
+	Name       string
+	Properties []Property
+	Children   []*Component
+}
+
+// NewComponent returns an empty component with the given name
+func NewComponent(name string) *Component {
+	return &Component{Name: name}
+}
+
+// Add appends a property whose value is written verbatim (dates, numbers,
+// URIs, ...). Params are given as "NAME=value" pairs.
+func (c *Component) Add(name, value string, params ...string) *Component {
+	prop := Property{Name: name, Value: value}
+	for _, p := range params {
+		k, v, _ := strings.Cut(p, "=")
+		prop.Params = append(prop.Params, Param{Name: k, Value: v})
+	}
+	c.Properties = append(c.Properties, prop)
+	return c
+}
+
+// AddText appends a TEXT property, escaping the value
+func (c *Component) AddText(name, text string, params ...string) *Component {
+	return c.Add(name, EscapeText(text), params...)
+}
+
+// AddTextList appends a multi-valued TEXT property (e.g., CATEGORIES),
+// escaping each value and separating them with unescaped commas
+func (c *Component) AddTextList(name string, texts []string, params ...string) *Component {
+	escaped := make([]string, len(texts))
+	for i, text := range texts {
+		escaped[i] = EscapeText(text)
+	}
+	return c.Add(name, strings.Join(escaped, ","), params...)
+}
+
+// AddChild appends a nested component
+func (c *Component) AddChild(child *Component) *Component {
+	c.Children = append(c.Children, child)
+	return c
+}
+
+// Get returns the first property with the given name, or nil
+func (c *Component) Get(name string) *Property {
+	for i := range c.Properties {
+		if strings.EqualFold(c.Properties[i].Name, name) {
+			return &c.Properties[i]
+		}
+	}
+	return nil
+}
+
+// Param returns the value of the named parameter, or ""
+func (p *Property) Param(name string) string {
+	for _, param := range p.Params {
+		if strings.EqualFold(param.Name, name) {
+			return param.Value
+		}
+	}
+	return ""
+}
+
+// Text returns the property value with TEXT escaping removed
+func (p *Property) Text() string {
+	return UnescapeText(p.Value)
+}
+
+// String renders the property as a single unfolded content line
+func (p Property) String() string {
+	var b strings.Builder
+	b.WriteString(p.Name)
+	for _, param := range p.Params {
+		b.WriteString(";" + param.Name + "=")
+		if strings.ContainsAny(param.Value, ":;,") {
+			b.WriteString(`"` + param.Value + `"`)
+		} else {
+			b.WriteString(param.Value)
+		}
+	}
+	b.WriteString(":" + p.Value)
+	return b.String()
+}
+
+// WriteTo writes the component and its children as folded CRLF lines
+func (c *Component) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	write := func(line string) error {
+		n, err := io.WriteString(w, Fold(line)+"\r\n")
+		total += int64(n)
+		return err
+	}
+
+	if err := write("BEGIN:" + c.Name); err != nil {
+		return total, err
+	}
+	for _, prop := range c.Properties {
+		if err := write(prop.String()); err != nil {
+			return total, err
+		}
+	}
+	for _, child := range c.Children {
+		n, err := child.WriteTo(w)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	err := write("END:" + c.Name)
+	return total, err
+}
+
+// String renders the component as iCalendar text
+func (c *Component) String() string {
+	var b strings.Builder
+	c.WriteTo(&b)
+	return b.String()
+}
+
+// EscapeText escapes a TEXT value (backslash, comma, semicolon, newline)
+func EscapeText(text string) string {
+	text = strings.ReplaceAll(text, "\\", "\\\\")
+	text = strings.ReplaceAll(text, ",", "\\,")
+	text = strings.ReplaceAll(text, ";", "\\;")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "")
+	text = strings.ReplaceAll(text, "\n", "\\n")
+	return text
+}
+
+// UnescapeText reverses EscapeText
+func UnescapeText(text string) string {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] != '\\' || i == len(text)-1 {
+			b.WriteByte(text[i])
+			continue
+		}
+		i++
+		switch text[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(text[i])
+		}
+	}
+	return b.String()
+}
+
+// Fold splits a content line into chunks of at most 75 octets joined by
+// CRLF + space, never splitting a UTF-8 sequence
+func Fold(line string) string {
+	if len(line) <= maxLineOctets {
+		return line
+	}
+
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward the limit
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(line)
+	return b.String()
+}
+
+// Parse reads iCalendar data and returns its top-level component. It is
+// lenient about bare LF line endings so it can read feeds from other sources.
+func Parse(r io.Reader) (*Component, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var root *Component
+	var stack []*Component
+	for _, l := range lines {
+		prop, err := parseLine(l.text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", l.number, err)
+		}
+
+		switch strings.ToUpper(prop.Name) {
+		case "BEGIN":
+			comp := NewComponent(strings.ToUpper(prop.Value))
+			if len(stack) > 0 {
+				stack[len(stack)-1].AddChild(comp)
+			} else if root != nil {
+				return nil, fmt.Errorf("line %d: more than one top-level component", l.number)
+			} else {
+				root = comp
+			}
+			stack = append(stack, comp)
+		case "END":
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: END:%s without BEGIN", l.number, prop.Value)
+			}
+			top := stack[len(stack)-1]
+			if !strings.EqualFold(top.Name, prop.Value) {
+				return nil, fmt.Errorf("line %d: END:%s does not match BEGIN:%s", l.number, prop.Value, top.Name)
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: property %s outside of a component", l.number, prop.Name)
+			}
+			prop.Name = strings.ToUpper(prop.Name)
+			stack[len(stack)-1].Properties = append(stack[len(stack)-1].Properties, prop)
+		}
+	}
+
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("missing END:%s", stack[len(stack)-1].Name)
+	}
+	if root == nil {
+		return nil, fmt.Errorf("no iCalendar data")
+	}
+	return root, nil
+}
+
+type contentLine struct {
+	number int
+	text   string
+}
+
+// unfold joins continuation lines (those starting with a space or tab)
+func unfold(r io.Reader) ([]contentLine, error) {
+	var lines []contentLine
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+		if text == "" {
+			continue
+		}
+		if (text[0] == ' ' || text[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		lines = append(lines, contentLine{number: number, text: text})
+	}
+	return lines, scanner.Err()
+}
+
+// parseLine splits "NAME;PARAM=value:VALUE" into a Property, honoring quoted
+// parameter values that may contain ':' or ';'
+func parseLine(line string) (Property, error) {
+	var prop Property
+
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return prop, fmt.Errorf("malformed content line %q", line)
+	}
+	prop.Name = line[:i]
+
+	for line[i] == ';' {
+		rest := line[i+1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq <= 0 {
+			return prop, fmt.Errorf("malformed parameter in %q", line)
+		}
+		param := Param{Name: strings.ToUpper(rest[:eq])}
+		j := i + 1 + eq + 1
+		if j < len(line) && line[j] == '"' {
+			end := strings.IndexByte(line[j+1:], '"')
+			if end < 0 {
+				return prop, fmt.Errorf("unterminated quoted parameter in %q", line)
+			}
+			param.Value = line[j+1 : j+1+end]
+			j = j + 1 + end + 1
+		} else {
+			end := strings.IndexAny(line[j:], ";:")
+			if end < 0 {
+				return prop, fmt.Errorf("missing value in %q", line)
+			}
+			param.Value = line[j : j+end]
+			j += end
+		}
+		prop.Params = append(prop.Params, param)
+		if j >= len(line) {
+			return prop, fmt.Errorf("missing value in %q", line)
+		}
+		i = j
+	}
+
+	if line[i] != ':' {
+		return prop, fmt.Errorf("malformed content line %q", line)
+	}
+	prop.Value = line[i+1:]
+	return prop, nil
+}
@@ -0,0 +1,156 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"Court 1, Gym A; bring water", `Court 1\, Gym A\; bring water`},
+		{`C:\path`, `C:\\path`},
+		{"line one\nline two", `line one\nline two`},
+		{"windows\r\nbreak", `windows\nbreak`},
+	}
+	for _, test := range tests {
+		got := EscapeText(test.in)
+		if got != test.want {
+			t.Errorf("EscapeText(%q) = %q, want %q", test.in, got, test.want)
+		}
+		if back := UnescapeText(got); back != strings.ReplaceAll(test.in, "\r\n", "\n") {
+			t.Errorf("UnescapeText(%q) = %q, want %q", got, back, test.in)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+	}{
+		{"short", "SUMMARY:10U Blue vs Sky"},
+		{"exactly 75", "DESCRIPTION:" + strings.Repeat("a", 63)},
+		{"long ASCII", "DESCRIPTION:" + strings.Repeat("abcdefghij", 20)},
+		{"multibyte at the boundary", "SUMMARY:" + strings.Repeat("x", 66) + strings.Repeat("⚡", 40)},
+	}
+	for _, test := range tests {
+		folded := Fold(test.line)
+		chunks := strings.Split(folded, "\r\n")
+		for i, chunk := range chunks {
+			if len(chunk) > maxLineOctets {
+				t.Errorf("%s: chunk %d is %d octets", test.name, i, len(chunk))
+			}
+			if i > 0 && !strings.HasPrefix(chunk, " ") {
+				t.Errorf("%s: continuation %d doesn't start with a space", test.name, i)
+			}
+			if !utf8.ValidString(chunk) {
+				t.Errorf("%s: chunk %d splits a UTF-8 sequence", test.name, i)
+			}
+		}
+		if len(test.line) <= maxLineOctets && folded != test.line {
+			t.Errorf("%s: short line was folded: %q", test.name, folded)
+		}
+		if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != test.line {
+			t.Errorf("%s: unfolding gives %q", test.name, unfolded)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	data := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"UID:game-1@example.com\r\n" +
+		"DTSTART;TZID=America/Chicago:20251018T090000\r\n" +
+		"SUMMARY:10U Blue vs Sky\\, Omaha\r\n" +
+		"DESCRIPTION:A long description that was folded\r\n" +
+		"  across two lines\r\n" +
+		"LOCATION;ALTREP=\"http://example.com/a:b;c\":Ralston Arena\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	cal, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cal.Name != "VCALENDAR" || len(cal.Children) != 1 {
+		t.Fatalf("got %s with %d children", cal.Name, len(cal.Children))
+	}
+	event := cal.Children[0]
+	tests := []struct {
+		name, param, wantParam, wantText string
+	}{
+		{"UID", "", "", "game-1@example.com"},
+		{"DTSTART", "TZID", "America/Chicago", "20251018T090000"},
+		{"SUMMARY", "", "", "10U Blue vs Sky, Omaha"},
+		{"DESCRIPTION", "", "", "A long description that was folded across two lines"},
+		{"LOCATION", "ALTREP", "http://example.com/a:b;c", "Ralston Arena"},
+	}
+	for _, test := range tests {
+		prop := event.Get(test.name)
+		if prop == nil {
+			t.Errorf("missing %s", test.name)
+			continue
+		}
+		if got := prop.Text(); got != test.wantText {
+			t.Errorf("%s = %q, want %q", test.name, got, test.wantText)
+		}
+		if test.param != "" && prop.Param(test.param) != test.wantParam {
+			t.Errorf("%s %s = %q, want %q", test.name, test.param, prop.Param(test.param), test.wantParam)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name, data string
+	}{
+		{"empty", ""},
+		{"unbalanced", "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nEND:VCALENDAR\r\n"},
+		{"missing END", "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"},
+		{"END without BEGIN", "END:VEVENT\r\n"},
+		{"property outside component", "VERSION:2.0\r\n"},
+		{"two top-level components", "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\nBEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"},
+		{"malformed line", "BEGIN:VCALENDAR\r\n:no name\r\nEND:VCALENDAR\r\n"},
+	}
+	for _, test := range tests {
+		if _, err := Parse(strings.NewReader(test.data)); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
+
+func TestWriteParseRoundTrip(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cal := NewComponent("VCALENDAR").
+		Add("VERSION", "2.0").
+		Add("PRODID", "-//Test//EN")
+	cal.AddChild(Timezone(chicago, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	summary := "10U Blue vs Sky; " + strings.Repeat("long, ", 20) + "⚡"
+	cal.AddChild(NewComponent("VEVENT").
+		Add("UID", "game-1@example.com").
+		Add("DTSTAMP", "20251001T120000Z").
+		Add("DTSTART", "20251018T090000", "TZID=America/Chicago").
+		AddText("SUMMARY", summary).
+		AddTextList("CATEGORIES", []string{"Fall Classic", "Pool A, Court 2"}))
+
+	data := []byte(cal.String())
+	if err := Validate(data); err != nil {
+		t.Fatalf("Validate: %v\n%s", err, data)
+	}
+	parsed, err := Parse(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := parsed.Children[1].Get("SUMMARY").Text(); got != summary {
+		t.Errorf("SUMMARY round trip = %q, want %q", got, summary)
+	}
+}
@@ -0,0 +1,69 @@
+package ical
+
+import (
+	"fmt"
+	"time"
+)
+
+// Timezone builds a VTIMEZONE for loc from Go's timezone database, covering
+// every offset transition between from and to. Each transition becomes its
+// own STANDARD/DAYLIGHT rule with an explicit DTSTART, which avoids having
+// to reverse-engineer RRULEs and stays correct when a zone changes its rules.
+func Timezone(loc *time.Location, from, to time.Time) *Component {
+	tz := NewComponent("VTIMEZONE").Add("TZID", loc.String())
+
+	// Read from's wall clock in loc so the range starts at local midnight
+	from = time.Date(from.Year(), from.Month(), from.Day(), from.Hour(), from.Minute(), from.Second(), 0, loc)
+	t := from
+	start, end := t.ZoneBounds()
+
+	// Rule for the zone already in effect at the start of the range
+	name, offset := t.Zone()
+	if start.IsZero() || start.Before(from) {
+		start = from
+	}
+	tz.AddChild(zoneRule(t.IsDST(), name, offset, offset, start.In(loc)))
+
+	for !end.IsZero() && !end.After(to) {
+		_, prevOffset := t.Zone()
+		t = end.In(loc)
+		name, offset := t.Zone()
+
+		// DTSTART is the wall-clock time of the transition in the old offset
+		wall := t.In(time.FixedZone("", prevOffset))
+		tz.AddChild(zoneRule(t.IsDST(), name, prevOffset, offset, wall))
+
+		_, end = t.ZoneBounds()
+	}
+
+	return tz
+}
+
+func zoneRule(isDST bool, name string, offsetFrom, offsetTo int, wall time.Time) *Component {
+	kind := "STANDARD"
+	if isDST {
+		kind = "DAYLIGHT"
+	}
+	rule := NewComponent(kind).
+		Add("DTSTART", wall.Format("20060102T150405")).
+		Add("TZOFFSETFROM", formatOffset(offsetFrom)).
+		Add("TZOFFSETTO", formatOffset(offsetTo))
+	if name != "" {
+		rule.AddText("TZNAME", name)
+	}
+	return rule
+}
+
+// formatOffset renders seconds east of UTC as an iCal UTC-OFFSET (e.g., -0500)
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	hours, minutes := seconds/3600, (seconds%3600)/60
+	if rem := seconds % 60; rem != 0 {
+		return fmt.Sprintf("%s%02d%02d%02d", sign, hours, minutes, rem)
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, hours, minutes)
+}
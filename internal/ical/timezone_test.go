@@ -0,0 +1,70 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestTimezone(t *testing.T) {
+	tests := []struct {
+		zone     string
+		from, to time.Time
+		want     []string // KIND DTSTART FROM TO NAME for each rule
+	}{
+		{"America/Chicago", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), []string{
+			"STANDARD 20250101T000000 -0600 -0600 CST",
+			"DAYLIGHT 20250309T020000 -0600 -0500 CDT",
+			"STANDARD 20251102T020000 -0500 -0600 CST",
+		}},
+		// Starting in summer, the first rule is daylight time
+		{"America/Chicago", time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), []string{
+			"DAYLIGHT 20250701T000000 -0500 -0500 CDT",
+			"STANDARD 20251102T020000 -0500 -0600 CST",
+			"DAYLIGHT 20260308T020000 -0600 -0500 CDT",
+		}},
+		// No daylight saving time: one rule for the whole range
+		{"America/Phoenix", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), []string{
+			"STANDARD 20250101T000000 -0700 -0700 MST",
+		}},
+		{"Asia/Kolkata", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), []string{
+			"STANDARD 20250101T000000 +0530 +0530 IST",
+		}},
+	}
+	for _, test := range tests {
+		loc, err := time.LoadLocation(test.zone)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tz := Timezone(loc, test.from, test.to)
+		if tzid := tz.Get("TZID"); tzid == nil || tzid.Value != test.zone {
+			t.Errorf("%s: TZID = %v", test.zone, tzid)
+		}
+
+		var rules []string
+		for _, rule := range tz.Children {
+			fields := []string{rule.Name}
+			for _, name := range []string{"DTSTART", "TZOFFSETFROM", "TZOFFSETTO", "TZNAME"} {
+				value := ""
+				if p := rule.Get(name); p != nil {
+					value = p.Value
+				}
+				fields = append(fields, value)
+			}
+			rules = append(rules, strings.Join(fields, " "))
+		}
+		if strings.Join(rules, "\n") != strings.Join(test.want, "\n") {
+			t.Errorf("%s from %s:\n got %q\nwant %q", test.zone, test.from.Format("2006-01-02"), rules, test.want)
+		}
+	}
+}
+
+func TestFormatOffset(t *testing.T) {
+	tests := map[int]string{0: "+0000", -6 * 3600: "-0600", 5*3600 + 30*60: "+0530", -(3*3600 + 30*60): "-0330", 3600 + 15: "+010015"}
+	for seconds, want := range tests {
+		if got := formatOffset(seconds); got != want {
+			t.Errorf("formatOffset(%d) = %q, want %q", seconds, got, want)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	dateValue     = regexp.MustCompile(`^\d{8}$`)
+	dateTimeValue = regexp.MustCompile(`^\d{8}T\d{6}Z?$`)
+)
+
+// Validate checks that data is a well-formed iCalendar feed: CRLF line
+// endings, lines folded at 75 octets, balanced components, the properties
+// calendar apps require, and TZID references that resolve to a VTIMEZONE.
+func Validate(data []byte) error {
+	for i, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		if !bytes.HasSuffix(line, []byte("\r\n")) {
+			return fmt.Errorf("line %d: not terminated by CRLF", i+1)
+		}
+		if n := len(line) - 2; n > maxLineOctets {
+			return fmt.Errorf("line %d: %d octets exceeds the %d octet limit", i+1, n, maxLineOctets)
+		}
+	}
+
+	cal, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if cal.Name != "VCALENDAR" {
+		return fmt.Errorf("top-level component is %s, not VCALENDAR", cal.Name)
+	}
+	for _, name := range []string{"VERSION", "PRODID"} {
+		if cal.Get(name) == nil {
+			return fmt.Errorf("VCALENDAR is missing %s", name)
+		}
+	}
+
+	timezones := make(map[string]bool)
+	for _, child := range cal.Children {
+		if child.Name != "VTIMEZONE" {
+			continue
+		}
+		tzid := child.Get("TZID")
+		if tzid == nil {
+			return fmt.Errorf("VTIMEZONE is missing TZID")
+		}
+		if len(child.Children) == 0 {
+			return fmt.Errorf("VTIMEZONE %s has no STANDARD or DAYLIGHT rules", tzid.Value)
+		}
+		for _, rule := range child.Children {
+			for _, name := range []string{"DTSTART", "TZOFFSETFROM", "TZOFFSETTO"} {
+				if rule.Get(name) == nil {
+					return fmt.Errorf("VTIMEZONE %s %s is missing %s", tzid.Value, rule.Name, name)
+				}
+			}
+		}
+		timezones[tzid.Value] = true
+	}
+
+	for _, child := range cal.Children {
+		if child.Name != "VEVENT" {
+			continue
+		}
+		uid := child.Get("UID")
+		if uid == nil || uid.Value == "" {
+			return fmt.Errorf("VEVENT is missing UID")
+		}
+		for _, name := range []string{"DTSTAMP", "DTSTART"} {
+			if child.Get(name) == nil {
+				return fmt.Errorf("VEVENT %s is missing %s", uid.Value, name)
+			}
+		}
+		for _, name := range []string{"DTSTAMP", "DTSTART", "DTEND", "LAST-MODIFIED"} {
+			prop := child.Get(name)
+			if prop == nil {
+				continue
+			}
+			if err := validateDateProperty(prop, timezones); err != nil {
+				return fmt.Errorf("VEVENT %s: %v", uid.Value, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateDateProperty(prop *Property, timezones map[string]bool) error {
+	if strings.EqualFold(prop.Param("VALUE"), "DATE") {
+		if !dateValue.MatchString(prop.Value) {
+			return fmt.Errorf("%s %q is not a DATE", prop.Name, prop.Value)
+		}
+		return nil
+	}
+	if !dateTimeValue.MatchString(prop.Value) {
+		return fmt.Errorf("%s %q is not a DATE-TIME", prop.Name, prop.Value)
+	}
+	if tzid := prop.Param("TZID"); tzid != "" {
+		if !timezones[tzid] {
+			return fmt.Errorf("%s references undefined TZID %s", prop.Name, tzid)
+		}
+		if strings.HasSuffix(prop.Value, "Z") {
+			return fmt.Errorf("%s has both TZID and a UTC time", prop.Name)
+		}
+	}
+	return nil
+}
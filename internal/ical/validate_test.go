@@ -0,0 +1,62 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+)
+
+// feed joins lines with CRLF into a calendar with one event
+func feed(eventLines ...string) string {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Test//EN",
+		"BEGIN:VTIMEZONE",
+		"TZID:America/Chicago",
+		"BEGIN:STANDARD",
+		"DTSTART:20251102T020000",
+		"TZOFFSETFROM:-0500",
+		"TZOFFSETTO:-0600",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+	}
+	lines = append(lines, eventLines...)
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+	return strings.Join(lines, "\r\n") + "\r\n"
+}
+
+func TestValidate(t *testing.T) {
+	valid := []string{"UID:game-1@example.com", "DTSTAMP:20251001T120000Z", "DTSTART;TZID=America/Chicago:20251018T090000"}
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"valid", feed(valid...), ""},
+		{"all-day", feed("UID:a@b", "DTSTAMP:20251001T120000Z", "DTSTART;VALUE=DATE:20251018"), ""},
+		{"bare LF", strings.ReplaceAll(feed(valid...), "\r\n", "\n"), "CRLF"},
+		{"long line", feed(append(valid, "SUMMARY:"+strings.Repeat("x", 80))...), "octet limit"},
+		{"not a calendar", "BEGIN:VEVENT\r\nEND:VEVENT\r\n", "not VCALENDAR"},
+		{"missing PRODID", strings.Replace(feed(valid...), "PRODID:-//Test//EN\r\n", "", 1), "PRODID"},
+		{"missing UID", feed(valid[1:]...), "missing UID"},
+		{"missing DTSTAMP", feed(valid[0], valid[2]), "DTSTAMP"},
+		{"bad DATE", feed("UID:a@b", "DTSTAMP:20251001T120000Z", "DTSTART;VALUE=DATE:2025-10-18"), "not a DATE"},
+		{"bad DATE-TIME", feed("UID:a@b", "DTSTAMP:20251001", "DTSTART:20251018T090000"), "not a DATE-TIME"},
+		{"undefined TZID", feed("UID:a@b", "DTSTAMP:20251001T120000Z", "DTSTART;TZID=America/Denver:20251018T090000"), "undefined TZID"},
+		{"TZID with UTC", feed("UID:a@b", "DTSTAMP:20251001T120000Z", "DTSTART;TZID=America/Chicago:20251018T090000Z"), "both TZID"},
+		{"unbalanced", strings.Replace(feed(valid...), "END:VEVENT\r\n", "", 1), "does not match"},
+	}
+	for _, test := range tests {
+		err := Validate([]byte(test.data))
+		switch {
+		case test.wantErr == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", test.name, err)
+		case test.wantErr != "" && err == nil:
+			t.Errorf("%s: expected an error containing %q", test.name, test.wantErr)
+		case test.wantErr != "" && !strings.Contains(err.Error(), test.wantErr):
+			t.Errorf("%s: error %q should contain %q", test.name, err, test.wantErr)
+		}
+	}
+}
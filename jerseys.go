@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"regexp"
+)
+
+// Colors a jersey swatch can use: a hex code or a plain CSS color name
+var jerseyColorRegex = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|[a-zA-Z]+)$`)
+
+// Jersey is one of a team's jersey sets
+type Jersey struct {
+	Name     string // e.g. "Gold"; what the sheet's Jersey column says
+	Emoji    string // Shown on schedule pages (e.g., "🟨")
+	Color    string // Swatch color for pages when there's no emoji (e.g., "#f5c400")
+	Calendar string // Text for calendar events (e.g., "Gold (Alternate)")
+	Side     string // "Home" or "Away" when tournaments assign this set by side
+}
+
+// fetchJerseys reads the Jerseys tab (Team, Name, and optional Emoji, Color,
+// Calendar and Side) and adds the sets to the teams. Rows without a Team
+// apply to every team, after the team's own sets.
+func fetchJerseys() error {
+	headers, records, err := fetchSheetTab("Jerseys")
+	if err != nil {
+		return err
+	}
+
+	var clubJerseys []Jersey
+	for _, record := range records {
+		teamName := getCellValue(headers, record, "Team")
+		jersey := Jersey{
+			Name:     getCellValue(headers, record, "Name"),
+			Emoji:    getCellValue(headers, record, "Emoji"),
+			Color:    getCellValue(headers, record, "Color"),
+			Calendar: getCellValue(headers, record, "Calendar"),
+			Side:     homeAwayFromJersey(getCellValue(headers, record, "Side")),
+		}
+
+		// Skip rows with missing data
+		if jersey.Name == "" {
+			continue
+		}
+
+		if jersey.Color != "" && !jerseyColorRegex.MatchString(jersey.Color) {
+			addBuildWarning("invalid color %q for %s jersey", jersey.Color, jersey.Name)
+			jersey.Color = ""
+		}
+
+		if teamName == "" {
+			clubJerseys = append(clubJerseys, jersey)
+			continue
+		}
+		team := findTeamByName(teamName)
+		if team == nil {
+			addBuildWarning("unknown team %q in jerseys", teamName)
+			continue
+		}
+		team.Jerseys = append(team.Jerseys, jersey)
+	}
+
+	for i := range AllTeams {
+		AllTeams[i].Jerseys = append(AllTeams[i].Jerseys, clubJerseys...)
+	}
+
+	return nil
+}
+
+// FindJersey returns the team's jersey set with the given name, or nil
+func (t *Team) FindJersey(name string) *Jersey {
+	key := nameKey(name)
+	if key == "" {
+		return nil
+	}
+	for i := range t.Jerseys {
+		if nameKey(t.Jerseys[i].Name) == key {
+			return &t.Jerseys[i]
+		}
+	}
+	return nil
+}
+
+// JerseyForSide returns the set the team wears as the home or away team,
+// which is how tournaments decide colors for games we didn't enter
+func (t *Team) JerseyForSide(homeAway string) *Jersey {
+	if homeAway == "" {
+		return nil
+	}
+	for i := range t.Jerseys {
+		if t.Jerseys[i].Side == homeAway {
+			return &t.Jerseys[i]
+		}
+	}
+	return nil
+}
+
+// applyJersey records what a Jersey cell says about a game: a named set
+// (and its side, if it has one) or just home/away from "Light"/"Dark". Text
+// that says neither leaves the game alone and reports false.
+func applyJersey(game *Game, text string) bool {
+	if jersey := game.Team.FindJersey(text); jersey != nil {
+		game.Jersey = jersey.Name
+		if jersey.Side != "" {
+			game.HomeAway = jersey.Side
+		}
+		return true
+	}
+	if homeAway := homeAwayFromJersey(text); homeAway != "" {
+		game.Jersey = ""
+		game.HomeAway = homeAway
+		return true
+	}
+	return false
+}
+
+// gameJersey returns the set a game is played in: the one named for it, or
+// the one for its side
+func gameJersey(game *Game) *Jersey {
+	if game.Team == nil {
+		return nil
+	}
+	if jersey := game.Team.FindJersey(game.Jersey); jersey != nil {
+		return jersey
+	}
+	return game.Team.JerseyForSide(game.HomeAway)
+}
+
+// jerseyHTML shows a game's jersey on schedule pages: the set's emoji or
+// color swatch, falling back to light/dark squares for home/away
+func jerseyHTML(game *Game) template.HTML {
+	jersey := gameJersey(game)
+	switch {
+	case jersey == nil:
+		return template.HTML(template.HTMLEscapeString(formatJersey(game, "html")))
+	case jersey.Emoji != "":
+		return template.HTML(fmt.Sprintf(`<span title="%s">%s</span>`, template.HTMLEscapeString(jersey.Name), template.HTMLEscapeString(jersey.Emoji)))
+	case jersey.Color != "":
+		return template.HTML(fmt.Sprintf(`<span class="jersey-swatch" style="background: %s" title="%s"></span>`, jersey.Color, template.HTMLEscapeString(jersey.Name)))
+	}
+	return template.HTML(template.HTMLEscapeString(jersey.Name))
+}
+
+// jerseyCalendarText describes a game's jersey in calendar events
+func jerseyCalendarText(game *Game) string {
+	jersey := gameJersey(game)
+	switch {
+	case jersey == nil:
+		return formatJersey(game, "cal")
+	case jersey.Calendar != "":
+		return jersey.Calendar
+	}
+	return jersey.Name
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Games listed by more than one source within this many minutes of each
+// other (or with a TBD time) on the same day can be the same game
+const duplicateTimeWindow = 15
+
+// Merge is a duplicate game dropped in favor of the same game from a source
+// with higher precedence
+type Merge struct {
+	Kept    Game
+	Dropped Game
+}
+
+// String describes the merge for the merge report
+func (m Merge) String() string {
+	return fmt.Sprintf("%s: kept %s, dropped %s%s", describeGame(&m.Kept), m.Kept.Origin, m.Dropped.Origin, sourceIDNote(m.Dropped.SourceID))
+}
+
+func sourceIDNote(id string) string {
+	if id == "" {
+		return ""
+	}
+	return " (" + id + ")"
+}
+
+// originRank orders sources by mergePrecedence; unlisted sources come last
+func originRank(origin string) int {
+	names := strings.Split(mergePrecedence, ",")
+	for i, name := range names {
+		if strings.EqualFold(strings.TrimSpace(name), origin) {
+			return i
+		}
+	}
+	return len(names)
+}
+
+// checkMergePrecedence rejects a precedence list naming a source we don't
+// read, which would otherwise just rank last without a word
+func checkMergePrecedence(precedence string) error {
+	for _, name := range strings.Split(precedence, ",") {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "sheet", "tourneymachine", "exposure", "ical":
+		default:
+			return fmt.Errorf("unknown source %q (use sheet, tourneymachine, exposure or ical)", strings.TrimSpace(name))
+		}
+	}
+	return nil
+}
+
+// mergeDuplicateGames drops games that another source already lists: same
+// team and day, close enough in time, and a similar opponent. The game from
+// the source with higher precedence wins and fills any blanks (score,
+// location, tournament details) from the one it replaces.
+func mergeDuplicateGames(allGames []Game) ([]Game, []Merge) {
+	var merged []Game
+	var merges []Merge
+	dropped := make([]bool, len(allGames))
+
+	for i := range allGames {
+		if dropped[i] {
+			continue
+		}
+		game := allGames[i]
+		for j := i + 1; j < len(allGames); j++ {
+			if dropped[j] || !isDuplicateGame(&game, &allGames[j]) {
+				continue
+			}
+			kept, other := game, allGames[j]
+			if originRank(other.Origin) < originRank(kept.Origin) {
+				kept, other = other, kept
+			}
+			fillGameBlanks(&kept, &other)
+			merges = append(merges, Merge{Kept: kept, Dropped: other})
+			game = kept
+			dropped[j] = true
+		}
+		merged = append(merged, game)
+	}
+
+	return merged, merges
+}
+
+// isDuplicateGame reports whether two games look like the same game for the
+// same team. Games from one source are only duplicates if they share an ID
+// (e.g., a page linked twice); otherwise that source is listing two games.
+// IDs name the game, not the team, so two of our teams playing each other
+// share one but are two games on our schedules.
+func isDuplicateGame(a, b *Game) bool {
+	if a.Team.Slug != b.Team.Slug {
+		return false
+	}
+	if a.Origin == b.Origin {
+		return a.SourceID != "" && a.SourceID == b.SourceID
+	}
+	// Sources spell dates differently ("October 05" vs "October 5")
+	dateA, dateB := parseDateForSorting(a.Date), parseDateForSorting(b.Date)
+	if !dateA.Equal(dateB) || (dateA.Year() == 2099 && a.Date != b.Date) {
+		return false
+	}
+	minutesA, minutesB := parseTimeToMinutes(a.Time), parseTimeToMinutes(b.Time)
+	if minutesA != 9999 && minutesB != 9999 && abs(minutesA-minutesB) > duplicateTimeWindow {
+		return false
+	}
+	return similarOpponents(a.Opponent, b.Opponent)
+}
+
+// similarOpponents compares opponent names loosely: a TBD or bracket slot
+// matches anyone, otherwise one name must contain the other or most of their
+// words must be shared ("Omaha Sky 12U" and "Sky 12U Elite")
+func similarOpponents(a, b string) bool {
+	for _, name := range []string{a, b} {
+		if name == "" || strings.EqualFold(name, "TBD") || isBracketPlaceholder(name) {
+			return true
+		}
+	}
+	keyA, keyB := nameKey(a), nameKey(b)
+	if strings.Contains(keyA, keyB) || strings.Contains(keyB, keyA) {
+		return true
+	}
+
+	wordsA := strings.Fields(strings.ToLower(a))
+	wordsB := make(map[string]bool)
+	for _, word := range strings.Fields(strings.ToLower(b)) {
+		wordsB[word] = true
+	}
+	shared := 0
+	for _, word := range wordsA {
+		if wordsB[word] {
+			shared++
+		}
+	}
+	return shared*2 >= min(len(wordsA), len(wordsB))+1
+}
+
+// fillGameBlanks copies what the kept game is missing from the dropped one
+func fillGameBlanks(kept, dropped *Game) {
+	if (kept.Time == "" || kept.Time == "TBD") && dropped.Time != "" {
+		kept.Time = dropped.Time
+	}
+	if kept.Location == nil {
+		kept.Location, kept.CourtGymInfo = dropped.Location, dropped.CourtGymInfo
+	} else if kept.CourtGymInfo == "" && kept.Location == dropped.Location {
+		kept.CourtGymInfo = dropped.CourtGymInfo
+	}
+	if kept.Opponent == "" || strings.EqualFold(kept.Opponent, "TBD") || isBracketPlaceholder(kept.Opponent) {
+		kept.Opponent = dropped.Opponent
+	}
+	if kept.HomeAway == "" {
+		kept.HomeAway = dropped.HomeAway
+	}
+	if kept.Jersey == "" {
+		kept.Jersey = dropped.Jersey
+	}
+	if kept.Result == "" && dropped.Result != "" {
+		kept.Score, kept.Result = dropped.Score, dropped.Result
+	}
+	if kept.Tournament == "" {
+		kept.Tournament, kept.Division, kept.Round = dropped.Tournament, dropped.Division, dropped.Round
+	}
+	if kept.Season == "" {
+		kept.Season = dropped.Season
+	}
+	// Keep a stable calendar UID when the winning source has no ID of its own
+	if kept.SourceID == "" {
+		kept.SourceID = dropped.SourceID
+	}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// writeMergeReport writes one line per merged duplicate to a plain-text report
+func writeMergeReport(merges []Merge, outputFile string) error {
+	var report strings.Builder
+	report.WriteString(fmt.Sprintf("Duplicate games merged as of %s (precedence: %s)\n\n", time.Now().UTC().Format("1/2/06 3:04PM UTC"), mergePrecedence))
+	if len(merges) == 0 {
+		report.WriteString("No duplicates found.\n")
+	}
+	for _, merge := range merges {
+		report.WriteString(merge.String() + "\n")
+	}
+
+	err := os.WriteFile(outputFile, []byte(report.String()), 0644)
+	if err != nil {
+		return fmt.Errorf("error writing merge report: %v", err)
+	}
+	return nil
+}
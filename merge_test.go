@@ -0,0 +1,82 @@
+package main
+
+import "testing"
+
+func TestMergeDuplicateGames(t *testing.T) {
+	setupTestClub(t)
+	defer func(precedence string) { mergePrecedence = precedence }(mergePrecedence)
+	gold, blue := &AllTeams[0], &AllTeams[1]
+	date := "Saturday, October 18, 2025"
+
+	games := []Game{
+		{Team: gold, Date: date, Time: "9:00 AM", Opponent: "Omaha Sky", Origin: "sheet", Jersey: "Gold"},
+		{Team: gold, Date: date, Time: "9:10 AM", Opponent: "Sky", Origin: "tourneymachine", SourceID: "tm-h1-12", Score: "W 40-31", Result: "W"},
+		// Our two teams playing each other share the game's ID
+		{Team: gold, Date: date, Time: "2:00 PM", Opponent: "Lightning 10U Blue", Origin: "tourneymachine", SourceID: "tm-h1-20"},
+		{Team: blue, Date: date, Time: "2:00 PM", Opponent: "Lightning 12U Gold", Origin: "tourneymachine", SourceID: "tm-h1-20"},
+		// A doubleheader from one source is two games
+		{Team: gold, Date: date, Time: "4:00 PM", Opponent: "Storm", Origin: "exposure"},
+		{Team: gold, Date: date, Time: "4:10 PM", Opponent: "Storm", Origin: "exposure"},
+	}
+
+	tests := []struct {
+		precedence string
+		wantOrigin string
+	}{
+		{"sheet,tourneymachine,exposure,ical", "sheet"},
+		{"tourneymachine,sheet", "tourneymachine"},
+	}
+	for _, test := range tests {
+		mergePrecedence = test.precedence
+		merged, merges := mergeDuplicateGames(games)
+		if len(merged) != 5 || len(merges) != 1 {
+			t.Fatalf("precedence %s: got %d games and %d merges, want 5 and 1", test.precedence, len(merged), len(merges))
+		}
+		sky := merged[0]
+		if sky.Origin != test.wantOrigin {
+			t.Errorf("precedence %s: kept the %s copy, want %s", test.precedence, sky.Origin, test.wantOrigin)
+		}
+		// Either way the kept game picks up what the other copy knew
+		if sky.Jersey != "Gold" || sky.Result != "W" || sky.SourceID != "tm-h1-12" {
+			t.Errorf("precedence %s: merged game = %+v", test.precedence, sky)
+		}
+	}
+}
+
+func TestCheckMergePrecedence(t *testing.T) {
+	for _, ok := range []string{"sheet,tourneymachine,exposure,ical", "ical, Sheet", "exposure"} {
+		if err := checkMergePrecedence(ok); err != nil {
+			t.Errorf("checkMergePrecedence(%q) = %v", ok, err)
+		}
+	}
+	for _, bad := range []string{"sheet,tournymachine", "sheet,,ical", ""} {
+		if err := checkMergePrecedence(bad); err == nil {
+			t.Errorf("checkMergePrecedence(%q) should fail", bad)
+		}
+	}
+}
+
+func TestIsDuplicateGameComparesDates(t *testing.T) {
+	setupTestClub(t)
+	team := &AllTeams[0]
+
+	tests := []struct {
+		dateA, dateB string
+		want         bool
+	}{
+		{"Sunday, October 5, 2025", "Sunday, October 05, 2025", true},
+		{"Sunday, October 5, 2025", "10/5/2025", true},
+		{"Sunday, October 5, 2025", "10/05/25", true},
+		{"Sunday, October 5, 2025", "Saturday, October 4, 2025", false},
+		{"TBD", "TBD", true},
+		{"TBD", "Sunday, October 5, 2025", false},
+		{"TBD", "Week 3", false},
+	}
+	for _, test := range tests {
+		a := Game{Team: team, Date: test.dateA, Time: "9:00 AM", Opponent: "Storm", Origin: "sheet"}
+		b := Game{Team: team, Date: test.dateB, Time: "9:00 AM", Opponent: "Storm", Origin: "exposure"}
+		if got := isDuplicateGame(&a, &b); got != test.want {
+			t.Errorf("isDuplicateGame(%q, %q) = %v, want %v", test.dateA, test.dateB, got, test.want)
+		}
+	}
+}
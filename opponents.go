@@ -0,0 +1,341 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Suffix of the file that keeps every meeting we have ever seen, so history
+// survives games dropping out of the sources. Like the event revisions it
+// sits next to the output directory (e.g. ".dist-opponent-history.json").
+const opponentHistoryFile = "-opponent-history.json"
+
+// Opponent aliases keyed by normalized name, pointing at the canonical name
+var OpponentAliases = map[string]string{}
+
+// Slugs of the opponents with a page, so schedules only link to those
+var OpponentPages = map[string]bool{}
+
+// Meeting is one game against an opponent, stored independently of Team
+// pointers so it can outlive the teams and sources of its season
+type Meeting struct {
+	Date     string `json:"date"` // "Monday, January 2, 2006"
+	Time     string `json:"time"`
+	Team     string `json:"team"`
+	TeamSlug string `json:"teamSlug"`
+	Opponent string `json:"opponent"`
+	HomeAway string `json:"homeAway,omitempty"`
+	Location string `json:"location,omitempty"`
+	Score    string `json:"score,omitempty"`
+	Result   string `json:"result,omitempty"`
+	Season   string `json:"season,omitempty"`
+	SourceID string `json:"sourceId,omitempty"`
+}
+
+// key identifies a meeting by its source's game ID when it has one, so a
+// pool game and a bracket game against the same team on one day stay two
+// meetings. IDs name the game rather than the team, hence the team slug.
+func (m Meeting) key() string {
+	if m.SourceID != "" {
+		return strings.Join([]string{m.TeamSlug, m.SourceID}, "|")
+	}
+	return m.slotKey()
+}
+
+// slotKey identifies a meeting by its day, team, opponent and start time
+func (m Meeting) slotKey() string {
+	return strings.Join([]string{parseDateForSorting(m.Date).Format("20060102"), m.TeamSlug, slugify(m.Opponent), strconv.Itoa(parseTimeToMinutes(m.Time))}, "|")
+}
+
+// OpponentSummary is a row on the opponents index and the header of an opponent page
+type OpponentSummary struct {
+	Name     string
+	Slug     string
+	Wins     int
+	Losses   int
+	Meetings []Meeting
+}
+
+// Record returns the club's W-L record against the opponent
+func (o OpponentSummary) Record() string {
+	return fmt.Sprintf("%d-%d", o.Wins, o.Losses)
+}
+
+type OpponentsTemplateData struct {
+	PageTitle      string
+	UpdatedDisplay string
+	Opponents      []OpponentSummary // Index page
+	Opponent       *OpponentSummary  // Opponent page
+	StylesCSS      template.CSS
+}
+
+// normalizeName lowercases and collapses whitespace for alias matching
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// canonicalOpponent maps an opponent alias to its canonical name
+func canonicalOpponent(name string) string {
+	if canonical, ok := OpponentAliases[normalizeName(name)]; ok {
+		return canonical
+	}
+	return strings.TrimSpace(name)
+}
+
+// normalizeOpponents rewrites every game's opponent to its canonical name
+func normalizeOpponents(allGames []Game) {
+	for i := range allGames {
+		allGames[i].Opponent = canonicalOpponent(allGames[i].Opponent)
+	}
+}
+
+// fetchOpponents reads the Opponents tab: a canonical Name and the other
+// spellings sources use for it in Aliases (separated by semicolons)
+func fetchOpponents() (map[string]string, error) {
+	headers, records, err := fetchSheetTab("Opponents")
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := make(map[string]string)
+	for _, record := range records {
+		name := getCellValue(headers, record, "Name")
+
+		// Skip rows with missing data
+		if name == "" {
+			continue
+		}
+
+		aliases[normalizeName(name)] = name
+		for _, alias := range strings.Split(getCellValue(headers, record, "Aliases"), ";") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				aliases[normalizeName(alias)] = name
+			}
+		}
+	}
+
+	return aliases, nil
+}
+
+// mergeMeetings adds the current games to the stored meetings. Games in the
+// current data replace stored copies (scores get filled in, times move);
+// stored meetings no longer in the data are kept as history once they have
+// a result. Unplayed ones that dropped out were rescheduled, removed or
+// hidden, so they're dropped rather than kept as games that never happened.
+// Stored meetings saved before they had a source ID are matched by slot.
+func mergeMeetings(history []Meeting, allGames []Game) []Meeting {
+	var current []Meeting
+	currentKeys := make(map[string]bool)
+	currentSlots := make(map[string]bool)
+	for _, game := range allGames {
+		if game.Opponent == "" || strings.EqualFold(game.Opponent, "TBD") || parseDateForSorting(game.Date).Year() == 2099 {
+			continue
+		}
+		// Possible bracket games only count once they're actually on
+		if game.Condition != "" || isBracketPlaceholder(game.Opponent) {
+			continue
+		}
+		m := meetingFromGame(&game)
+		m.Opponent = canonicalOpponent(m.Opponent)
+		current = append(current, m)
+		currentKeys[m.key()] = true
+		currentSlots[m.slotKey()] = true
+	}
+
+	merged := make(map[string]Meeting)
+	var order []string
+	add := func(m Meeting) {
+		k := m.key()
+		if _, ok := merged[k]; !ok {
+			order = append(order, k)
+		}
+		merged[k] = m
+	}
+
+	for _, m := range history {
+		m.Opponent = canonicalOpponent(m.Opponent)
+		if m.SourceID == "" && currentSlots[m.slotKey()] {
+			continue
+		}
+		if m.Result == "" && !currentKeys[m.key()] {
+			continue
+		}
+		add(m)
+	}
+	for _, m := range current {
+		add(m)
+	}
+
+	meetings := make([]Meeting, 0, len(order))
+	for _, k := range order {
+		meetings = append(meetings, merged[k])
+	}
+	sort.SliceStable(meetings, func(i, j int) bool {
+		dateA, dateB := parseDateForSorting(meetings[i].Date), parseDateForSorting(meetings[j].Date)
+		if !dateA.Equal(dateB) {
+			return dateA.After(dateB) // Most recent first
+		}
+		return parseTimeToMinutes(meetings[i].Time) > parseTimeToMinutes(meetings[j].Time)
+	})
+	return meetings
+}
+
+func meetingFromGame(game *Game) Meeting {
+	location := ""
+	if game.Location != nil {
+		location = game.Location.Name
+	}
+	return Meeting{
+		Date:     game.Date,
+		Time:     game.Time,
+		Team:     game.Team.Name,
+		TeamSlug: game.Team.Slug,
+		Opponent: game.Opponent,
+		HomeAway: game.HomeAway,
+		Location: location,
+		Score:    game.Score,
+		Result:   game.Result,
+		Season:   game.Season,
+		SourceID: game.SourceID,
+	}
+}
+
+func loadMeetings(path string) ([]Meeting, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", path, err)
+	}
+
+	var meetings []Meeting
+	if err := json.Unmarshal(data, &meetings); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+	return meetings, nil
+}
+
+func saveMeetings(path string, meetings []Meeting) error {
+	data, err := json.MarshalIndent(meetings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing %s: %v", path, err)
+	}
+	return nil
+}
+
+// summarizeOpponents groups meetings by opponent, alphabetically
+func summarizeOpponents(meetings []Meeting) []OpponentSummary {
+	byName := make(map[string]*OpponentSummary)
+	for _, m := range meetings {
+		summary := byName[m.Opponent]
+		if summary == nil {
+			summary = &OpponentSummary{Name: m.Opponent, Slug: slugify(m.Opponent)}
+			byName[m.Opponent] = summary
+		}
+		summary.Meetings = append(summary.Meetings, m)
+		switch m.Result {
+		case "W":
+			summary.Wins++
+		case "L":
+			summary.Losses++
+		}
+	}
+
+	var summaries []OpponentSummary
+	for _, summary := range byName {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return strings.ToLower(summaries[i].Name) < strings.ToLower(summaries[j].Name)
+	})
+	return summaries
+}
+
+// updateOpponentHistory adds the current games to the stored history, saves
+// it and notes which opponents get a page, returning every meeting
+func updateOpponentHistory(allGames []Game, historyPath string) ([]Meeting, error) {
+	history, err := loadMeetings(historyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	meetings := mergeMeetings(history, allGames)
+	OpponentPages = make(map[string]bool)
+	for _, m := range meetings {
+		if slug := slugify(m.Opponent); slug != "" {
+			OpponentPages[slug] = true
+		}
+	}
+	return meetings, saveMeetings(historyPath, meetings)
+}
+
+// generateOpponents writes an index of opponents plus a page per opponent
+// listing every meeting
+func generateOpponents(meetings []Meeting, outputDir string) error {
+	tmpl, err := template.New("opponents").Parse(opponentsTemplate)
+	if err != nil {
+		return fmt.Errorf("error parsing template: %v", err)
+	}
+
+	now := time.Now().UTC()
+	data := OpponentsTemplateData{
+		PageTitle:      "Lightning Opponents",
+		UpdatedDisplay: now.Format("1/2/06") + " at " + now.Format("3:04PM") + " UTC",
+		Opponents:      summarizeOpponents(meetings),
+		StylesCSS:      template.CSS(stylesCSS),
+	}
+
+	err = executeTemplateToFile(tmpl, data, filepath.Join(outputDir, "index.html"))
+	if err != nil {
+		return err
+	}
+
+	for i := range data.Opponents {
+		opponent := &data.Opponents[i]
+		if opponent.Slug == "" {
+			continue
+		}
+
+		opponentDir := filepath.Join(outputDir, opponent.Slug)
+		err = os.MkdirAll(opponentDir, 0755)
+		if err != nil {
+			return fmt.Errorf("error creating opponent directory: %v", err)
+		}
+
+		page := data
+		page.PageTitle = "Lightning vs " + opponent.Name
+		page.Opponents = nil
+		page.Opponent = opponent
+		err = executeTemplateToFile(tmpl, page, filepath.Join(opponentDir, "index.html"))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func executeTemplateToFile(tmpl *template.Template, data any, outputFile string) error {
+	f, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer f.Close()
+
+	err = tmpl.Execute(f, data)
+	if err != nil {
+		return fmt.Errorf("error executing template: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeMeetingsDropsUnplayedGamesThatDisappeared(t *testing.T) {
+	setupTestClub(t)
+	OpponentAliases = map[string]string{"sky": "Omaha Sky", "omaha sky": "Omaha Sky"}
+	team := &AllTeams[0]
+
+	history := []Meeting{
+		// Played last month; the source no longer lists it
+		{Date: "Saturday, September 13, 2025", Time: "9:00 AM", Team: team.Name, TeamSlug: team.Slug, Opponent: "Sky", Score: "W 40-31", Result: "W"},
+		// Never played: moved to another day
+		{Date: "Saturday, October 18, 2025", Time: "9:00 AM", Team: team.Name, TeamSlug: team.Slug, Opponent: "Storm"},
+		// Still scheduled; the current copy replaces it
+		{Date: "Sunday, October 19, 2025", Time: "1:00 PM", Team: team.Name, TeamSlug: team.Slug, Opponent: "Hawks"},
+	}
+	games := []Game{
+		{Team: team, Date: "Saturday, October 25, 2025", Time: "9:00 AM", Opponent: "Storm"},
+		{Team: team, Date: "Sunday, October 19, 2025", Time: "2:00 PM", Opponent: "Hawks", Score: "L 30-35", Result: "L"},
+		// Possible bracket games and TBD opponents aren't meetings yet
+		{Team: team, Date: "Sunday, October 19, 2025", Time: "4:00 PM", Opponent: "Winner of Game 12", Condition: "If 12U Gold wins Game 12"},
+		{Team: team, Date: "Sunday, November 2, 2025", Time: "TBD", Opponent: "TBD"},
+	}
+
+	meetings := mergeMeetings(history, games)
+
+	want := []struct{ date, time, opponent, result string }{
+		{"Saturday, October 25, 2025", "9:00 AM", "Storm", ""},
+		{"Sunday, October 19, 2025", "2:00 PM", "Hawks", "L"},
+		{"Saturday, September 13, 2025", "9:00 AM", "Omaha Sky", "W"},
+	}
+	if len(meetings) != len(want) {
+		t.Fatalf("got %d meetings %+v, want %d", len(meetings), meetings, len(want))
+	}
+	for i, w := range want {
+		m := meetings[i]
+		if m.Date != w.date || m.Time != w.time || m.Opponent != w.opponent || m.Result != w.result {
+			t.Errorf("meeting %d = %s %s %s %q, want %s %s %s %q", i, m.Date, m.Time, m.Opponent, m.Result, w.date, w.time, w.opponent, w.result)
+		}
+	}
+}
+
+func TestMergeMeetingsKeepsTwoGamesAgainstOneOpponentOnADay(t *testing.T) {
+	setupTestClub(t)
+	gold, blue := &AllTeams[0], &AllTeams[1]
+	date := "Saturday, October 18, 2025"
+
+	history := []Meeting{
+		// Saved before meetings kept source IDs; the current pool game replaces it
+		{Date: date, Time: "9:00 AM", Team: gold.Name, TeamSlug: gold.Slug, Opponent: "Storm", Score: "W 40-31", Result: "W"},
+	}
+	games := []Game{
+		// Pool game, then the bracket game against the same team
+		{Team: gold, Date: date, Time: "9:00 AM", Opponent: "Storm", SourceID: "tm-h1-12", Score: "W 40-31", Result: "W"},
+		{Team: gold, Date: date, Time: "3:00 PM", Opponent: "Storm", SourceID: "tm-h1-30", Score: "L 28-33", Result: "L"},
+		// Same for a source without IDs, told apart by start time
+		{Team: blue, Date: date, Time: "10:00 AM", Opponent: "Hawks", Score: "W 22-20", Result: "W"},
+		{Team: blue, Date: date, Time: "1:00 PM", Opponent: "Hawks", Score: "W 30-12", Result: "W"},
+	}
+
+	meetings := mergeMeetings(history, games)
+	if len(meetings) != 4 {
+		t.Fatalf("got %d meetings %+v, want 4", len(meetings), meetings)
+	}
+	summaries := summarizeOpponents(meetings)
+	records := map[string]string{}
+	for _, summary := range summaries {
+		records[summary.Name] = summary.Record()
+	}
+	if records["Storm"] != "1-1" || records["Hawks"] != "2-0" {
+		t.Errorf("records = %v, want Storm 1-1 and Hawks 2-0", records)
+	}
+
+	// Saving and merging again changes nothing
+	again := mergeMeetings(meetings, games)
+	if len(again) != 4 {
+		t.Errorf("second merge got %d meetings, want 4", len(again))
+	}
+}
+
+func TestScheduleLinksOnlyToOpponentPages(t *testing.T) {
+	setupTestClub(t)
+	defer func() { OpponentPages = map[string]bool{} }()
+	team := &AllTeams[0]
+
+	games := []Game{
+		{Team: team, Date: "Saturday, October 18, 2025", Time: "9:00 AM", Opponent: "Omaha Sky"},
+		// A possible bracket game against a known team isn't a meeting yet
+		{Team: team, Date: "Sunday, October 19, 2025", Time: "1:00 PM", Opponent: "Storm", Condition: "If 12U Gold wins Game 12"},
+		{Team: team, Date: "Sunday, October 19, 2025", Time: "3:00 PM", Opponent: "Winner of Game 14"},
+		{Team: team, Date: "TBD", Time: "TBD", Opponent: "Hawks"},
+	}
+	if _, err := updateOpponentHistory(games, filepath.Join(t.TempDir(), "history.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	var page bytes.Buffer
+	if err := renderHTML(&page, games, nil, AllGamesFilter()); err != nil {
+		t.Fatal(err)
+	}
+	html := page.String()
+	if !strings.Contains(html, `href="/opponents/omaha-sky/"`) {
+		t.Error("no link to the Omaha Sky page")
+	}
+	for _, slug := range []string{"storm", "winner-of-game-14", "hawks"} {
+		if strings.Contains(html, `href="/opponents/`+slug+`/"`) {
+			t.Errorf("links to /opponents/%s/, which has no page", slug)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Override patches or hides a game we can't fix at its source, such as a
+// tournament page listing the wrong gym. It finds its game by team and date
+// plus either the opponent or the source's game number.
+type Override struct {
+	Row      int // Sheet row, for warnings
+	Team     *Team
+	Date     string // "Monday, January 2, 2006"
+	Opponent string
+	GameNum  string // Game number on the source page (e.g., TourneyMachine "Game #")
+	Time     string
+	Location *Location
+	Court    string
+	Jersey   string
+	Hide     bool
+}
+
+// describe names the override's game in warnings
+func (o *Override) describe() string {
+	target := o.Opponent
+	if o.GameNum != "" {
+		target = "game " + o.GameNum
+	}
+	return fmt.Sprintf("row %d (%s, %s, %s)", o.Row, o.Team.Name, o.Date, target)
+}
+
+// fetchOverrides reads the Overrides tab. Each row names a game (Team, Date,
+// and Opponent or Game) and what to change: Time, Location, Court, Jersey,
+// or Hide to leave the game off every page and calendar.
+func fetchOverrides() ([]Override, error) {
+	headers, records, err := fetchSheetTab("Overrides")
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides []Override
+	for i, record := range records {
+		row := i + 2 // Header is row 1
+		teamName := getCellValue(headers, record, "Team")
+		dateText := getCellValue(headers, record, "Date")
+		opponent := getCellValue(headers, record, "Opponent")
+		gameNum := strings.TrimPrefix(getCellValue(headers, record, "Game"), "#")
+
+		// Skip blank rows
+		if teamName == "" && dateText == "" && opponent == "" && gameNum == "" {
+			continue
+		}
+
+		team := findTeamByName(teamName)
+		if team == nil {
+			addBuildWarning("Overrides row %d: unknown team %q", row, teamName)
+			continue
+		}
+		date := parseDateForSorting(dateText)
+		if date.Year() == 2099 {
+			addBuildWarning("Overrides row %d: invalid date %q", row, dateText)
+			continue
+		}
+		if opponent == "" && gameNum == "" {
+			addBuildWarning("Overrides row %d: needs an Opponent or Game to find the game", row)
+			continue
+		}
+
+		override := Override{
+			Row:      row,
+			Team:     team,
+			Date:     date.Format("Monday, January 2, 2006"),
+			Opponent: opponent,
+			GameNum:  strings.TrimSpace(gameNum),
+			Time:     getCellValue(headers, record, "Time"),
+			Court:    getCellValue(headers, record, "Court"),
+			Jersey:   getCellValue(headers, record, "Jersey"),
+		}
+
+		if override.Time != "" && !strings.EqualFold(override.Time, "TBD") && parseTimeToMinutes(override.Time) == 9999 {
+			addBuildWarning("Overrides %s: invalid time %q", override.describe(), override.Time)
+			override.Time = ""
+		}
+
+		if location := getCellValue(headers, record, "Location"); location != "" {
+			loc, court := findLocationByAbbrev(location)
+			if loc == nil {
+				loc, court = findLocationByName(location)
+			}
+			if loc == nil {
+				addBuildWarning("Overrides %s: unknown location %q", override.describe(), location)
+			} else {
+				override.Location = loc
+				if override.Court == "" {
+					override.Court = court
+				}
+			}
+		}
+
+		switch strings.ToLower(getCellValue(headers, record, "Hide")) {
+		case "yes", "y", "true", "x", "hide":
+			override.Hide = true
+		}
+
+		overrides = append(overrides, override)
+	}
+
+	return overrides, nil
+}
+
+// matches reports whether the override is for this game. Opponents are
+// compared by canonical name, so any spelling from the Opponents tab works.
+func (o *Override) matches(game *Game) bool {
+	if game.Team != o.Team || game.Date != o.Date {
+		return false
+	}
+	if o.GameNum != "" {
+		return strings.HasSuffix(game.SourceID, "-"+slugify(o.GameNum))
+	}
+	return nameKey(canonicalOpponent(o.Opponent)) == nameKey(game.Opponent)
+}
+
+// applyOverrides patches and hides games as the Overrides tab says. An
+// override that matches no game (or several, e.g. a doubleheader against
+// the same opponent) is reported and left out rather than guessed at.
+func applyOverrides(allGames []Game, overrides []Override) []Game {
+	hidden := make([]bool, len(allGames))
+	for i := range overrides {
+		override := &overrides[i]
+
+		var matched []int
+		for j := range allGames {
+			if override.matches(&allGames[j]) {
+				matched = append(matched, j)
+			}
+		}
+		switch {
+		case len(matched) == 0:
+			addBuildWarning("Overrides %s matches no game", override.describe())
+			continue
+		case len(matched) > 1:
+			addBuildWarning("Overrides %s matches %d games; use the Game column to pick one", override.describe(), len(matched))
+			continue
+		}
+
+		game := &allGames[matched[0]]
+		if override.Hide {
+			hidden[matched[0]] = true
+			continue
+		}
+		if override.Time != "" {
+			game.Time = override.Time
+		}
+		if override.Location != nil {
+			game.Location = override.Location
+			game.CourtGymInfo = override.Court
+		} else if override.Court != "" {
+			game.CourtGymInfo = override.Court
+		}
+		if override.Jersey != "" && !applyJersey(game, override.Jersey) {
+			addBuildWarning("Overrides %s: %q isn't a %s jersey set or home/away", override.describe(), override.Jersey, game.Team.Name)
+		}
+	}
+
+	var kept []Game
+	for i, game := range allGames {
+		if !hidden[i] {
+			kept = append(kept, game)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApplyOverrides(t *testing.T) {
+	setupTestClub(t)
+	OpponentAliases = map[string]string{"sky": "Omaha Sky"}
+	team := &AllTeams[0]
+	team.Jerseys = []Jersey{{Name: "White", Side: "Home"}, {Name: "Gold"}, {Name: "Black", Side: "Away"}}
+	ralston := &AllLocations[0]
+	date := "Saturday, October 18, 2025"
+
+	games := []Game{
+		{Team: team, Date: date, Time: "9:00 AM", Opponent: "Omaha Sky", HomeAway: "Away", SourceID: "tm-h1-12"},
+		{Team: team, Date: date, Time: "11:00 AM", Opponent: "Hawks", HomeAway: "Home", SourceID: "tm-h1-14"},
+		{Team: team, Date: date, Time: "2:00 PM", Opponent: "Storm", HomeAway: "Away", SourceID: "tm-h1-16"},
+		{Team: team, Date: date, Time: "4:00 PM", Opponent: "Storm", HomeAway: "Home", SourceID: "tm-h1-18"},
+		{Team: team, Date: date, Time: "6:00 PM", Opponent: "Heat", HomeAway: "Away", SourceID: "tm-h1-20"},
+	}
+	overrides := []Override{
+		// Wrong gym on the tournament page, matched through an opponent alias
+		{Row: 2, Team: team, Date: date, Opponent: "sky", Location: ralston, Court: "Court 3", Time: "9:30 AM"},
+		// A named set without a side keeps the scraped home/away
+		{Row: 3, Team: team, Date: date, GameNum: "14", Jersey: "gold"},
+		// A doubleheader needs the game number
+		{Row: 4, Team: team, Date: date, Opponent: "Storm", Jersey: "Dark"},
+		{Row: 5, Team: team, Date: date, GameNum: "#18"[1:], Hide: true},
+		// Unknown jersey text is reported, not applied
+		{Row: 6, Team: team, Date: date, GameNum: "20", Jersey: "Teal"},
+		{Row: 7, Team: team, Date: date, Opponent: "Nobody"},
+	}
+
+	result := applyOverrides(games, overrides)
+
+	if len(result) != 4 {
+		t.Fatalf("got %d games, want 4 (one hidden)", len(result))
+	}
+	sky := result[0]
+	if sky.Location != ralston || sky.CourtGymInfo != "Court 3" || sky.Time != "9:30 AM" || sky.HomeAway != "Away" {
+		t.Errorf("sky game = %+v", sky)
+	}
+	if hawks := result[1]; hawks.Jersey != "Gold" || hawks.HomeAway != "Home" {
+		t.Errorf("hawks game jersey = %q, home/away = %q", hawks.Jersey, hawks.HomeAway)
+	}
+	if storm := result[2]; storm.SourceID != "tm-h1-16" || storm.HomeAway != "Away" {
+		t.Errorf("first Storm game = %+v", storm)
+	}
+	if heat := result[3]; heat.HomeAway != "Away" || heat.Jersey != "" {
+		t.Errorf("unknown jersey text changed the game: %+v", heat)
+	}
+
+	warnings := strings.Join(BuildWarnings, "\n")
+	for _, want := range []string{"row 4 (12U Gold, " + date + ", Storm) matches 2 games", `"Teal" isn't a 12U Gold jersey set`, "row 7 (12U Gold, " + date + ", Nobody) matches no game"} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("warnings missing %q:\n%s", want, warnings)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SheetBackend reads the club's spreadsheet one tab at a time. Rows come
+// back as text, header row first; short rows are fine (getCellValue treats
+// missing cells as empty).
+type SheetBackend interface {
+	ReadTab(tab string) ([][]string, error)
+}
+
+// Sheet is where every tab is read from; main swaps it for a private backend
+// when credentials are given
+var Sheet SheetBackend = &publicSheet{workbookURL: googleSheetXLSXURL}
+
+// Export URLs for the original tabs, which are exported by gid
+var publicSheetGIDURLs = map[string]string{
+	gamesTab:     googleSheetCSVURL,
+	notesTab:     googleSheetNotesCSVURL,
+	locationsTab: googleSheetLocationsCSVURL,
+	teamsTab:     googleSheetTeamsCSVURL,
+}
+
+// publicSheet reads tabs from the sheet's public export. Newer tabs have no
+// gid of their own here, so they come from an export of the whole workbook:
+// asking the CSV export for a tab by name returns the first tab when there's
+// no such tab (and blanks cells in columns that mix text and numbers).
+type publicSheet struct {
+	workbookURL string
+
+	workbookOnce sync.Once
+	workbook     *workbookSheet
+	workbookErr  error
+}
+
+// ReadTab downloads a tab as CSV, or finds it in the exported workbook
+func (s *publicSheet) ReadTab(tab string) ([][]string, error) {
+	url, ok := publicSheetGIDURLs[tab]
+	if !ok {
+		s.workbookOnce.Do(func() {
+			s.workbook, s.workbookErr = downloadWorkbook(s.workbookURL)
+		})
+		if s.workbookErr != nil {
+			return nil, s.workbookErr
+		}
+		return s.workbook.ReadTab(tab)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s sheet: %v", tab, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("received status code %d for %s sheet", resp.StatusCode, tab)
+	}
+
+	reader := csv.NewReader(resp.Body)
+	reader.FieldsPerRecord = -1
+
+	var rows [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// A bad header row means we didn't get CSV at all
+			if len(rows) == 0 {
+				return nil, fmt.Errorf("error reading CSV header: %v", err)
+			}
+			continue
+		}
+		rows = append(rows, record)
+	}
+
+	return rows, nil
+}
+
+// downloadWorkbook fetches the whole sheet as an .xlsx file and reads every tab
+func downloadWorkbook(url string) (*workbookSheet, error) {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching workbook export: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("received status code %d for workbook export", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching workbook export: %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("error reading workbook export: %v", err)
+	}
+	return readWorkbook(archive, "Google Sheet export.xlsx")
+}
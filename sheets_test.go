@@ -0,0 +1,61 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPublicSheetReadsNewerTabsFromWorkbookExport(t *testing.T) {
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		http.ServeFile(w, r, "testdata/workbook.xlsx")
+	}))
+	defer server.Close()
+
+	sheet := &publicSheet{workbookURL: server.URL}
+
+	rows, err := sheet.ReadTab("Overrides")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{{"Team", "Date", "Jersey"}, {"12U Gold", "10/18/2025", "Dark"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("Overrides = %q, want %q", rows, want)
+	}
+
+	// Tabs are matched by name, ignoring case and spaces
+	if _, err := sheet.ReadTab("TeamSources"); err != nil {
+		t.Error(err)
+	}
+
+	// A tab the club doesn't have is missing, not the first tab in disguise
+	if rows, err := sheet.ReadTab("Jerseys"); err == nil || !strings.Contains(err.Error(), "no Jerseys tab") {
+		t.Errorf("ReadTab(Jerseys) = %q, %v; want a missing-tab error", rows, err)
+	}
+
+	if downloads != 1 {
+		t.Errorf("downloaded the workbook %d times, want once", downloads)
+	}
+}
+
+func TestPublicSheetWorkbookExportErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/denied" {
+			http.Error(w, "sign in", http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("<html>not a workbook</html>"))
+	}))
+	defer server.Close()
+
+	for _, path := range []string{"/denied", "/html"} {
+		sheet := &publicSheet{workbookURL: server.URL + path}
+		if _, err := sheet.ReadTab("Overrides"); err == nil {
+			t.Errorf("%s: expected an error", path)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Where the Sheets API lives; the -sheets-api flag points it elsewhere (e.g.,
+// a local stub server)
+const sheetsAPIBaseURL = "https://sheets.googleapis.com"
+
+// Read-only access is all the generator needs
+const sheetsAPIScope = "https://www.googleapis.com/auth/spreadsheets.readonly"
+
+// Token endpoint for keys that don't name one
+const defaultTokenURL = "https://oauth2.googleapis.com/token"
+
+// serviceAccountKey is the part of a Google service account key file we use
+type serviceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// apiSheet reads tabs through the Sheets API v4 as a service account, so the
+// spreadsheet can stay private (shared only with the account's email)
+type apiSheet struct {
+	baseURL    string
+	tokenURL   string
+	email      string
+	privateKey *rsa.PrivateKey
+	client     *http.Client
+
+	token       string
+	tokenExpiry time.Time
+}
+
+// newAPISheet loads a service account key file (the JSON Google offers for
+// download) and returns a backend that reads through baseURL
+func newAPISheet(keyFile, baseURL string) (*apiSheet, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading service account key: %v", err)
+	}
+	var key serviceAccountKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("error parsing service account key: %v", err)
+	}
+	if key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, fmt.Errorf("service account key %s is missing client_email or private_key", keyFile)
+	}
+
+	privateKey, err := parseRSAPrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing service account private key: %v", err)
+	}
+
+	tokenURL := key.TokenURI
+	if tokenURL == "" {
+		tokenURL = defaultTokenURL
+	}
+
+	return &apiSheet{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		tokenURL:   tokenURL,
+		email:      key.ClientEmail,
+		privateKey: privateKey,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// parseRSAPrivateKey reads a PEM private key in PKCS #8 (what Google issues)
+// or PKCS #1 form
+func parseRSAPrivateKey(text string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(text))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// ReadTab fetches a tab's formatted values, the same text the CSV export has
+func (s *apiSheet) ReadTab(tab string) ([][]string, error) {
+	token, err := s.accessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// Quoting the tab name makes it a valid range even with spaces in it
+	sheetRange := "'" + strings.ReplaceAll(tab, "'", "''") + "'"
+	apiURL := fmt.Sprintf("%s/v4/spreadsheets/%s/values/%s?majorDimension=ROWS&valueRenderOption=FORMATTED_VALUE",
+		s.baseURL, googleSheetID, url.PathEscape(sheetRange))
+
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s sheet: %v", tab, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching %s sheet: %v", tab, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("received status code %d for %s sheet: %s", resp.StatusCode, tab, apiErrorMessage(resp.Body))
+	}
+
+	var values struct {
+		Values [][]string `json:"values"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&values); err != nil {
+		return nil, fmt.Errorf("error parsing %s sheet: %v", tab, err)
+	}
+	return values.Values, nil
+}
+
+// accessToken returns a cached OAuth token, trading a signed JWT for a new
+// one when it's about to expire
+func (s *apiSheet) accessToken() (string, error) {
+	now := time.Now()
+	if s.token != "" && now.Before(s.tokenExpiry.Add(-time.Minute)) {
+		return s.token, nil
+	}
+
+	assertion, err := s.signedJWT(now)
+	if err != nil {
+		return "", fmt.Errorf("error signing token request: %v", err)
+	}
+
+	resp, err := s.client.PostForm(s.tokenURL, url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error requesting access token: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("received status code %d requesting access token: %s", resp.StatusCode, apiErrorMessage(resp.Body))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("error parsing access token: %v", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("token response has no access_token")
+	}
+
+	s.token = token.AccessToken
+	s.tokenExpiry = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	return s.token, nil
+}
+
+// signedJWT builds the RS256-signed assertion for the service account
+// (RFC 7523), valid for an hour
+func (s *apiSheet) signedJWT(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iss":   s.email,
+		"scope": sheetsAPIScope,
+		"aud":   s.tokenURL,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// apiErrorMessage pulls the message out of a Google API error response,
+// falling back to the start of the body
+func apiErrorMessage(body io.Reader) string {
+	data, _ := io.ReadAll(io.LimitReader(body, 4096))
+	var apiErr struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if json.Unmarshal(data, &apiErr) == nil {
+		if apiErr.Error.Message != "" {
+			return apiErr.Error.Message
+		}
+		if apiErr.ErrorDescription != "" {
+			return apiErr.ErrorDescription
+		}
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// sheetsStub is a local stand-in for Google's token endpoint and Sheets API
+type sheetsStub struct {
+	t         *testing.T
+	key       *rsa.PrivateKey
+	tokenURL  string
+	tokens    int
+	tabs      map[string][][]string
+	lastRange string
+}
+
+func (s *sheetsStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/token" {
+		s.token(w, r)
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer stub-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"code":401,"message":"Request is missing valid authentication credentials."}}`))
+		return
+	}
+	prefix := "/v4/spreadsheets/" + googleSheetID + "/values/"
+	sheetRange, ok := strings.CutPrefix(r.URL.Path, prefix)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	s.lastRange = sheetRange
+	rows, ok := s.tabs[strings.Trim(sheetRange, "'")]
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":400,"message":"Unable to parse range: ` + sheetRange + `"}}`))
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{"range": sheetRange, "majorDimension": "ROWS", "values": rows})
+}
+
+// token checks the signed JWT the way Google does before handing out a token
+func (s *sheetsStub) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		s.t.Fatal(err)
+	}
+	if r.Form.Get("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+		s.t.Errorf("grant_type = %q", r.Form.Get("grant_type"))
+	}
+
+	parts := strings.Split(r.Form.Get("assertion"), ".")
+	if len(parts) != 3 {
+		s.t.Fatalf("assertion has %d parts", len(parts))
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&s.key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		s.t.Errorf("bad JWT signature: %v", err)
+	}
+
+	var header map[string]string
+	var claims map[string]any
+	decodeJWTPart(s.t, parts[0], &header)
+	decodeJWTPart(s.t, parts[1], &claims)
+	if header["alg"] != "RS256" {
+		s.t.Errorf("alg = %q", header["alg"])
+	}
+	if claims["iss"] != "schedule@club.iam.gserviceaccount.com" || claims["aud"] != s.tokenURL || claims["scope"] != sheetsAPIScope {
+		s.t.Errorf("claims = %v", claims)
+	}
+	if exp, iat := claims["exp"].(float64), claims["iat"].(float64); exp-iat != 3600 {
+		s.t.Errorf("token lifetime = %v", exp-iat)
+	}
+
+	s.tokens++
+	w.Write([]byte(`{"access_token":"stub-token","expires_in":3600,"token_type":"Bearer"}`))
+}
+
+func decodeJWTPart(t *testing.T, part string, v any) {
+	t.Helper()
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newSheetsStub starts the stub and writes a service account key file for it
+func newSheetsStub(t *testing.T) (*sheetsStub, *httptest.Server, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stub := &sheetsStub{t: t, key: key}
+	srv := httptest.NewServer(stub)
+	t.Cleanup(srv.Close)
+	stub.tokenURL = srv.URL + "/token"
+
+	keyFile := filepath.Join(t.TempDir(), "service-account.json")
+	data, _ := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "schedule@club.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":    stub.tokenURL,
+	})
+	if err := os.WriteFile(keyFile, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return stub, srv, keyFile
+}
+
+func TestAPISheetReadTab(t *testing.T) {
+	stub, srv, keyFile := newSheetsStub(t)
+	stub.tabs = map[string][][]string{
+		"Teams":        {{"Name", "Slug", "Parent Email"}, {"12U Gold", "12u-gold"}},
+		"Team Sources": {{"Team", "URL"}},
+	}
+
+	sheet, err := newAPISheet(keyFile, srv.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	Sheet = sheet
+	defer func(sheet SheetBackend) { Sheet = sheet }(Sheet)
+
+	headers, records, err := fetchSheetTab("Teams")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(headers, ",") != "Name,Slug,Parent Email" || len(records) != 1 || getCellValue(headers, records[0], "Slug") != "12u-gold" {
+		t.Errorf("got %q %q", headers, records)
+	}
+	// Short rows (trailing empty cells left out by the API) read as empty
+	if getCellValue(headers, records[0], "Parent Email") != "" {
+		t.Errorf("missing trailing cell should be empty")
+	}
+
+	// Tab names are quoted so spaces survive, and the token is reused
+	if _, _, err := fetchSheetTab("Team Sources"); err != nil {
+		t.Fatal(err)
+	}
+	if stub.lastRange != "'Team Sources'" {
+		t.Errorf("range = %q", stub.lastRange)
+	}
+	if stub.tokens != 1 {
+		t.Errorf("requested %d tokens, want 1", stub.tokens)
+	}
+
+	_, _, err = fetchSheetTab("Missing")
+	if err == nil || !strings.Contains(err.Error(), "status code 400") || !strings.Contains(err.Error(), "Unable to parse range") {
+		t.Errorf("err = %v, want the API's error message", err)
+	}
+}
+
+func TestAPISheetTokenErrors(t *testing.T) {
+	_, srv, keyFile := newSheetsStub(t)
+
+	// A token endpoint that refuses the key
+	refusing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid_grant","error_description":"Invalid JWT Signature."}`))
+	}))
+	defer refusing.Close()
+
+	sheet, err := newAPISheet(keyFile, srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheet.tokenURL = refusing.URL
+	_, err = sheet.ReadTab("Teams")
+	if err == nil || !strings.Contains(err.Error(), "Invalid JWT Signature") {
+		t.Errorf("err = %v, want the token endpoint's error", err)
+	}
+
+	if _, err := newAPISheet(filepath.Join(t.TempDir(), "missing.json"), srv.URL); err == nil {
+		t.Error("expected an error for a missing key file")
+	}
+	badKey := filepath.Join(t.TempDir(), "bad.json")
+	os.WriteFile(badKey, []byte(`{"client_email":"a@b","private_key":"not a key"}`), 0600)
+	if _, err := newAPISheet(badKey, srv.URL); err == nil {
+		t.Error("expected an error for a key without PEM data")
+	}
+}
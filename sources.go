@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Provider of a schedule page when a source doesn't name one and its URL
+// doesn't give it away
+const defaultProvider = "tourneymachine"
+
+// Source is one schedule page for a team, usually one event
+type Source struct {
+	URL      string
+	Provider string    // How to read the page (e.g., "tourneymachine")
+	Event    string    // Event name, used when the page doesn't give one
+	From     time.Time // First date of the event's games to keep; zero means unbounded
+	To       time.Time // Last date of the event's games to keep; zero means unbounded
+}
+
+// fetchTeamSources reads extra schedule links from the TeamSources tab (Team,
+// URL, and optional Event, From, To and Provider) and adds them to the teams
+func fetchTeamSources() error {
+	headers, records, err := fetchSheetTab("TeamSources")
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		teamName := getCellValue(headers, record, "Team")
+		url := getCellValue(headers, record, "URL")
+
+		// Skip rows with missing data
+		if teamName == "" || url == "" {
+			continue
+		}
+
+		team := findTeamByName(teamName)
+		if team == nil {
+			addBuildWarning("unknown team %q in team sources", teamName)
+			continue
+		}
+
+		source := Source{
+			URL:      url,
+			Provider: strings.ToLower(getCellValue(headers, record, "Provider")),
+			Event:    getCellValue(headers, record, "Event"),
+		}
+		if source.Provider == "" {
+			source.Provider = detectProvider(url)
+		}
+		for _, bound := range []struct {
+			column string
+			value  *time.Time
+		}{{"From", &source.From}, {"To", &source.To}} {
+			text := getCellValue(headers, record, bound.column)
+			if text == "" {
+				continue
+			}
+			date := parseDateForSorting(text)
+			if date.Year() == 2099 {
+				addBuildWarning("invalid %s date %q for %s source %s", bound.column, text, team.Name, url)
+				continue
+			}
+			*bound.value = date
+		}
+
+		// A link already in the Teams tab gets this row's event details
+		if existing := team.FindSource(url); existing != nil {
+			*existing = source
+		} else {
+			team.Sources = append(team.Sources, source)
+		}
+	}
+
+	return nil
+}
+
+// detectProvider guesses how to read a schedule link from its URL:
+// Exposure Events pages, calendar feeds, or TourneyMachine by default
+func detectProvider(url string) string {
+	lower := strings.ToLower(url)
+	switch {
+	case strings.Contains(lower, "exposureevents.com"):
+		return "exposure"
+	case strings.HasPrefix(lower, "webcal://"), strings.HasSuffix(strings.SplitN(lower, "?", 2)[0], ".ics"):
+		return "ical"
+	}
+	return defaultProvider
+}
+
+// gameScore formats our and the opponent's points the way schedules show them
+// ("W 45-30"); both are empty until the game has a score
+func gameScore(ours, theirs string) (score, result string) {
+	ourScore, err1 := strconv.Atoi(strings.TrimSpace(ours))
+	theirScore, err2 := strconv.Atoi(strings.TrimSpace(theirs))
+	if err1 != nil || err2 != nil {
+		return "", ""
+	}
+	result = "L"
+	if ourScore > theirScore {
+		result = "W"
+	}
+	return fmt.Sprintf("%s %d-%d", result, ourScore, theirScore), result
+}
+
+// FindSource returns the team's source for the given URL, or nil
+func (t *Team) FindSource(url string) *Source {
+	for i := range t.Sources {
+		if t.Sources[i].URL == url {
+			return &t.Sources[i]
+		}
+	}
+	return nil
+}
+
+// scrapeSource reads a team's games from one source with its provider and
+// applies the source's event name and date range
+func scrapeSource(team *Team, source Source) ([]Game, error) {
+	var games []Game
+	var err error
+	switch source.Provider {
+	case "tourneymachine":
+		games, err = scrapeTeamSchedule(team, source.URL)
+	case "exposure":
+		games, err = scrapeExposureSchedule(team, source.URL)
+	case "ical":
+		games, err = fetchICalGames(team, source.URL)
+	default:
+		return nil, fmt.Errorf("unknown provider %q for %s source %s", source.Provider, team.Name, source.URL)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var kept []Game
+	for _, game := range games {
+		date := parseDateForSorting(game.Date)
+		if date.Year() != 2099 &&
+			(!source.From.IsZero() && date.Before(source.From) || !source.To.IsZero() && date.After(source.To)) {
+			continue
+		}
+		if game.Tournament == "" {
+			game.Tournament = source.Event
+		}
+		game.Origin = source.Provider
+		kept = append(kept, game)
+	}
+	return kept, nil
+}
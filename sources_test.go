@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fakeSheet serves tabs from memory in place of the Google Sheet
+type fakeSheet map[string][][]string
+
+func (f fakeSheet) ReadTab(tab string) ([][]string, error) {
+	rows, ok := f[tab]
+	if !ok {
+		return nil, fmt.Errorf("no %s tab", tab)
+	}
+	return rows, nil
+}
+
+func TestFetchTeamSourcesAddsMetadataToTeamLinks(t *testing.T) {
+	setupTestClub(t)
+	team := &AllTeams[0]
+	team.Sources = []Source{{URL: "https://tourneymachine.com/Public/Results/Team.aspx?IDTournament=h1", Provider: defaultProvider}}
+
+	Sheet = fakeSheet{"TeamSources": {
+		{"Team", "URL", "Event", "From", "To", "Provider"},
+		{"12U Gold", "https://tourneymachine.com/Public/Results/Team.aspx?IDTournament=h1", "Fall Classic", "10/17/2025", "10/19/2025", ""},
+		{"12U Gold", "https://basketball.exposureevents.com/219842/fall-hoops/schedule", "Fall Hoops", "", "", ""},
+	}}
+	defer func(sheet SheetBackend) { Sheet = sheet }(Sheet)
+
+	if err := fetchTeamSources(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(team.Sources) != 2 {
+		t.Fatalf("got %d sources, want 2: %+v", len(team.Sources), team.Sources)
+	}
+	linked := team.Sources[0]
+	if linked.Event != "Fall Classic" || linked.Provider != "tourneymachine" ||
+		!linked.From.Equal(time.Date(2025, 10, 17, 0, 0, 0, 0, time.UTC)) ||
+		!linked.To.Equal(time.Date(2025, 10, 19, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Teams link didn't get the TeamSources details: %+v", linked)
+	}
+	if added := team.Sources[1]; added.Provider != "exposure" || added.Event != "Fall Hoops" {
+		t.Errorf("added source = %+v", added)
+	}
+}
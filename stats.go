@@ -0,0 +1,253 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Matches the "W 45-30" / "L 28-31" scores stored on played games
+var scoreRegex = regexp.MustCompile(`(\d+)\s*-\s*(\d+)`)
+
+// TeamStats summarizes a team's played games
+type TeamStats struct {
+	Team          *Team        `json:"-"`
+	Name          string       `json:"team"`
+	Slug          string       `json:"slug"`
+	Wins          int          `json:"wins"`
+	Losses        int          `json:"losses"`
+	HomeWins      int          `json:"homeWins"`
+	HomeLosses    int          `json:"homeLosses"`
+	AwayWins      int          `json:"awayWins"`
+	AwayLosses    int          `json:"awayLosses"`
+	PointsFor     int          `json:"pointsFor"`
+	PointsAgainst int          `json:"pointsAgainst"`
+	ScoredGames   int          `json:"scoredGames"` // Games with a parseable score
+	AvgMargin     float64      `json:"avgMargin"`
+	Streak        string       `json:"streak"` // e.g., "W3"
+	Last5         string       `json:"last5"`  // Oldest to newest, e.g., "WWLWL"
+	HeadToHead    []HeadToHead `json:"headToHead,omitempty"`
+}
+
+// HeadToHead is a team's record against an opponent it has played more than once
+type HeadToHead struct {
+	Opponent      string `json:"opponent"`
+	Wins          int    `json:"wins"`
+	Losses        int    `json:"losses"`
+	PointsFor     int    `json:"pointsFor"`
+	PointsAgainst int    `json:"pointsAgainst"`
+}
+
+// Points returns our and the opponent's points from the game's score
+func (g Game) Points() (ours, theirs int, ok bool) {
+	if g.Result == "" {
+		return 0, 0, false
+	}
+	match := scoreRegex.FindStringSubmatch(g.Score)
+	if match == nil {
+		return 0, 0, false
+	}
+	ours, _ = strconv.Atoi(match[1])
+	theirs, _ = strconv.Atoi(match[2])
+	return ours, theirs, true
+}
+
+// Record returns "W-L", e.g. "7-2"
+func (s TeamStats) Record() string {
+	return fmt.Sprintf("%d-%d", s.Wins, s.Losses)
+}
+
+// HomeRecord returns the W-L record in home games
+func (s TeamStats) HomeRecord() string {
+	return fmt.Sprintf("%d-%d", s.HomeWins, s.HomeLosses)
+}
+
+// AwayRecord returns the W-L record in away games
+func (s TeamStats) AwayRecord() string {
+	return fmt.Sprintf("%d-%d", s.AwayWins, s.AwayLosses)
+}
+
+// WinPct returns the fraction of games won
+func (s TeamStats) WinPct() float64 {
+	if s.Wins+s.Losses == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(s.Wins+s.Losses)
+}
+
+// FormattedMargin renders the average margin with a sign, e.g. "+6.5"
+func (s TeamStats) FormattedMargin() string {
+	if s.ScoredGames == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f", s.AvgMargin)
+}
+
+// computeTeamStats tallies the team's played games
+func computeTeamStats(team *Team, allGames []Game) TeamStats {
+	stats := TeamStats{Team: team, Name: team.Name, Slug: team.Slug}
+
+	// Played games in the order they happened, for streaks and last 5
+	var played []Game
+	for _, game := range allGames {
+		if game.Team.Slug == team.Slug && game.Result != "" {
+			played = append(played, game)
+		}
+	}
+	sort.SliceStable(played, func(i, j int) bool {
+		dateA, dateB := parseDateForSorting(played[i].Date), parseDateForSorting(played[j].Date)
+		if !dateA.Equal(dateB) {
+			return dateA.Before(dateB)
+		}
+		return parseTimeToMinutes(played[i].Time) < parseTimeToMinutes(played[j].Time)
+	})
+
+	h2h := make(map[string]*HeadToHead)
+	var opponents []string
+	meetings := make(map[string]int)
+	var results strings.Builder
+
+	for _, game := range played {
+		won := game.Result == "W"
+		results.WriteString(game.Result)
+
+		if won {
+			stats.Wins++
+		} else {
+			stats.Losses++
+		}
+		switch game.HomeAway {
+		case "Home":
+			if won {
+				stats.HomeWins++
+			} else {
+				stats.HomeLosses++
+			}
+		case "Away":
+			if won {
+				stats.AwayWins++
+			} else {
+				stats.AwayLosses++
+			}
+		}
+
+		key := strings.ToLower(strings.TrimSpace(game.Opponent))
+		if h2h[key] == nil {
+			h2h[key] = &HeadToHead{Opponent: game.Opponent}
+			opponents = append(opponents, key)
+		}
+		meetings[key]++
+		if won {
+			h2h[key].Wins++
+		} else {
+			h2h[key].Losses++
+		}
+
+		if ours, theirs, ok := game.Points(); ok {
+			stats.ScoredGames++
+			stats.PointsFor += ours
+			stats.PointsAgainst += theirs
+			h2h[key].PointsFor += ours
+			h2h[key].PointsAgainst += theirs
+		}
+	}
+
+	if stats.ScoredGames > 0 {
+		stats.AvgMargin = float64(stats.PointsFor-stats.PointsAgainst) / float64(stats.ScoredGames)
+	}
+
+	// Streak is the run of identical results ending with the latest game
+	sequence := results.String()
+	if sequence != "" {
+		last := sequence[len(sequence)-1]
+		count := 0
+		for i := len(sequence) - 1; i >= 0 && sequence[i] == last; i-- {
+			count++
+		}
+		stats.Streak = fmt.Sprintf("%c%d", last, count)
+	}
+	if len(sequence) > 5 {
+		sequence = sequence[len(sequence)-5:]
+	}
+	stats.Last5 = sequence
+
+	for _, key := range opponents {
+		if meetings[key] > 1 {
+			stats.HeadToHead = append(stats.HeadToHead, *h2h[key])
+		}
+	}
+
+	return stats
+}
+
+// computeStandings returns stats for every team, best record first
+func computeStandings(allGames []Game) []TeamStats {
+	var standings []TeamStats
+	for i := range AllTeams {
+		standings = append(standings, computeTeamStats(&AllTeams[i], allGames))
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.WinPct() != b.WinPct() {
+			return a.WinPct() > b.WinPct()
+		}
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		return a.Team.Order < b.Team.Order
+	})
+	return standings
+}
+
+type StandingsTemplateData struct {
+	PageTitle      string
+	UpdatedDisplay string
+	Standings      []TeamStats
+	StylesCSS      template.CSS
+}
+
+// generateStandings writes the club-wide standings page and the same data as JSON
+func generateStandings(allGames []Game, outputDir string) error {
+	standings := computeStandings(allGames)
+
+	data, err := json.MarshalIndent(standings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding standings: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(outputDir, "standings.json"), data, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing standings JSON: %v", err)
+	}
+
+	tmpl, err := template.New("standings").Parse(standingsTemplate)
+	if err != nil {
+		return fmt.Errorf("error parsing template: %v", err)
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, "index.html"))
+	if err != nil {
+		return fmt.Errorf("error creating file: %v", err)
+	}
+	defer f.Close()
+
+	now := time.Now().UTC()
+	err = tmpl.Execute(f, StandingsTemplateData{
+		PageTitle:      "Lightning Standings",
+		UpdatedDisplay: now.Format("1/2/06") + " at " + now.Format("3:04PM") + " UTC",
+		Standings:      standings,
+		StylesCSS:      template.CSS(stylesCSS),
+	})
+	if err != nil {
+		return fmt.Errorf("error executing template: %v", err)
+	}
+
+	return nil
+}
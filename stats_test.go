@@ -0,0 +1,85 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeTeamStats(t *testing.T) {
+	gold := &Team{Name: "12U Gold", Slug: "12u-gold"}
+	blue := &Team{Name: "10U Blue", Slug: "10u-blue"}
+
+	// Out of order, to check results are tallied in the order they happened
+	allGames := []Game{
+		{Team: gold, Date: "Saturday, January 24, 2026", Time: "9:00 AM", Opponent: "Storm", HomeAway: "Home", Result: "W", Score: "W 31-30"},
+		{Team: gold, Date: "Saturday, January 3, 2026", Time: "11:00 AM", Opponent: "Hawks", HomeAway: "Away", Result: "L", Score: "L 20-25"},
+		{Team: gold, Date: "Saturday, January 3, 2026", Time: "9:00 AM", Opponent: "Storm", HomeAway: "Home", Result: "W", Score: "W 40-30"},
+		{Team: gold, Date: "Sunday, January 4, 2026", Time: "10:00 AM", Opponent: " storm ", HomeAway: "Away", Result: "W", Score: "W 35 - 33"},
+		{Team: gold, Date: "Saturday, January 10, 2026", Time: "9:00 AM", Opponent: "Sky", Result: "W", Score: "W (forfeit)"},
+		{Team: gold, Date: "Saturday, January 17, 2026", Time: "9:00 AM", Opponent: "Hawks", HomeAway: "Home", Result: "W", Score: "W 50-40"},
+		// Not played yet
+		{Team: gold, Date: "Saturday, January 31, 2026", Time: "9:00 AM", Opponent: "Storm", HomeAway: "Away"},
+		// Another team's
+		{Team: blue, Date: "Saturday, January 31, 2026", Time: "9:00 AM", Opponent: "Storm", HomeAway: "Away", Result: "L", Score: "L 10-50"},
+	}
+
+	stats := computeTeamStats(gold, allGames)
+	if stats.Record() != "5-1" {
+		t.Errorf("Record() = %q, want 5-1", stats.Record())
+	}
+	if stats.Streak != "W4" || stats.Last5 != "LWWWW" {
+		t.Errorf("Streak, Last5 = %q, %q, want W4, LWWWW", stats.Streak, stats.Last5)
+	}
+	// The forfeit has no home/away and no points
+	if stats.HomeRecord() != "3-0" || stats.AwayRecord() != "1-1" {
+		t.Errorf("HomeRecord(), AwayRecord() = %q, %q, want 3-0, 1-1", stats.HomeRecord(), stats.AwayRecord())
+	}
+	if stats.PointsFor != 176 || stats.PointsAgainst != 158 || stats.ScoredGames != 5 {
+		t.Errorf("points = %d-%d in %d games, want 176-158 in 5", stats.PointsFor, stats.PointsAgainst, stats.ScoredGames)
+	}
+	if stats.FormattedMargin() != "+3.6" {
+		t.Errorf("FormattedMargin() = %q, want +3.6", stats.FormattedMargin())
+	}
+
+	// Opponents played more than once, in order of first meeting, with
+	// spellings folded together
+	want := []HeadToHead{
+		{Opponent: "Storm", Wins: 3, Losses: 0, PointsFor: 106, PointsAgainst: 93},
+		{Opponent: "Hawks", Wins: 1, Losses: 1, PointsFor: 70, PointsAgainst: 65},
+	}
+	if !reflect.DeepEqual(stats.HeadToHead, want) {
+		t.Errorf("HeadToHead = %+v, want %+v", stats.HeadToHead, want)
+	}
+
+	stats = computeTeamStats(blue, allGames)
+	if stats.Streak != "L1" || stats.Last5 != "L" || stats.FormattedMargin() != "-40.0" || stats.HeadToHead != nil {
+		t.Errorf("10U Blue stats = %+v", stats)
+	}
+}
+
+func TestComputeTeamStatsLosingStreak(t *testing.T) {
+	gold := &Team{Name: "12U Gold", Slug: "12u-gold"}
+	var allGames []Game
+	for _, game := range []struct{ time, result string }{
+		{"8:00 AM", "W"}, {"9:00 AM", "W"}, {"10:00 AM", "L"}, {"1:00 PM", "L"}, {"3:00 PM", "L"},
+	} {
+		allGames = append(allGames, Game{Team: gold, Date: "Saturday, January 3, 2026", Time: game.time, Opponent: "Storm", Result: game.result})
+	}
+
+	stats := computeTeamStats(gold, allGames)
+	if stats.Streak != "L3" || stats.Last5 != "WWLLL" {
+		t.Errorf("Streak, Last5 = %q, %q, want L3, WWLLL", stats.Streak, stats.Last5)
+	}
+	// No scores, so no margin
+	if stats.ScoredGames != 0 || stats.FormattedMargin() != "-" {
+		t.Errorf("ScoredGames, FormattedMargin() = %d, %q, want 0, -", stats.ScoredGames, stats.FormattedMargin())
+	}
+}
+
+func TestComputeTeamStatsNoGames(t *testing.T) {
+	gold := &Team{Name: "12U Gold", Slug: "12u-gold"}
+	stats := computeTeamStats(gold, nil)
+	if stats.Record() != "0-0" || stats.Streak != "" || stats.Last5 != "" || stats.WinPct() != 0 || stats.FormattedMargin() != "-" {
+		t.Errorf("stats = %+v, want none", stats)
+	}
+}
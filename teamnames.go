@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Participant names containing this word look like one of our teams
+const clubKeyword = "lightning"
+
+// nameKey lowercases and drops all whitespace, so "Lightning 12U  Gold" and
+// "lightning 12u gold" (or "Lightning12U Gold") compare equal
+func nameKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), "")
+}
+
+// parseTeamPatterns compiles the semicolon-separated regexes of a Teams row.
+// Patterns are case-insensitive and see names with whitespace collapsed.
+func parseTeamPatterns(text string) ([]*regexp.Regexp, error) {
+	var patterns []*regexp.Regexp
+	for _, pattern := range strings.Split(text, ";") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", pattern, err)
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns, nil
+}
+
+// MatchesName reports whether a name on a schedule page is this team: its
+// CBLName, one of its aliases, or a match for one of its patterns
+func (t *Team) MatchesName(name string) bool {
+	key := nameKey(name)
+	if key == "" {
+		return false
+	}
+	for _, alias := range append([]string{t.CBLName}, t.CBLAliases...) {
+		if nameKey(alias) == key {
+			return true
+		}
+	}
+	collapsed := strings.Join(strings.Fields(name), " ")
+	for _, pattern := range t.CBLPatterns {
+		if pattern.MatchString(collapsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// looksLikeOurs reports whether a participant could be one of our teams but
+// matches none of them, which usually means an alias is missing. Other clubs
+// with the same name can be listed in the Opponents tab to silence this.
+func looksLikeOurs(name string) bool {
+	if !strings.Contains(strings.ToLower(name), clubKeyword) {
+		return false
+	}
+	if _, ok := OpponentAliases[normalizeName(name)]; ok {
+		return false
+	}
+	for i := range AllTeams {
+		if AllTeams[i].MatchesName(name) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTeamMatchesName(t *testing.T) {
+	setupTestClub(t)
+	Sheet = fakeSheet{teamsTab: {
+		{"Name", "Slug", "CBLName", "CBLAliases", "CBLPatterns"},
+		{"12U Gold", "12u-gold", "Lightning 12U Gold", "Omaha Lightning 12U Gold; LNG 12 Gold ;", `^lightning 12u gold( - .*)?$; \bgold 2031\b`},
+		{"10U Blue", "10u-blue", "Lightning 10U Blue", "", `(unclosed`},
+	}}
+	defer func(sheet SheetBackend) { Sheet = sheet }(Sheet)
+
+	teams, err := fetchTeams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gold, blue := &teams[0], &teams[1]
+	if len(BuildWarnings) != 1 || !strings.Contains(BuildWarnings[0], "10U Blue: invalid pattern") {
+		t.Errorf("warnings = %q, want one about the 10U Blue pattern", BuildWarnings)
+	}
+
+	tests := []struct {
+		team *Team
+		name string
+		want bool
+	}{
+		{gold, "Lightning 12U Gold", true},
+		// Case and whitespace don't matter
+		{gold, "  lightning 12u   GOLD ", true},
+		{gold, "Lightning12U Gold", true},
+		// Aliases, trimmed
+		{gold, "Omaha Lightning 12U Gold", true},
+		{gold, "lng 12 gold", true},
+		// Patterns are case-insensitive and see collapsed whitespace
+		{gold, "Lightning 12U Gold - Spring", true},
+		{gold, "LIGHTNING  12U  GOLD  -  Black Hills", true},
+		{gold, "Lightning Gold 2031", true},
+		{gold, "Lightning 12U Gold Elite", false},
+		{gold, "Lightning 12U Black", false},
+		{gold, "", false},
+		{gold, "   ", false},
+		// A bad pattern is skipped without breaking the name match
+		{blue, "Lightning 10U Blue", true},
+		{blue, "(unclosed", false},
+	}
+	for _, test := range tests {
+		if got := test.team.MatchesName(test.name); got != test.want {
+			t.Errorf("%s MatchesName(%q) = %v, want %v", test.team.Name, test.name, got, test.want)
+		}
+	}
+}
+
+func TestLooksLikeOurs(t *testing.T) {
+	setupTestClub(t)
+	OpponentAliases = map[string]string{"lincoln lightning": "Lincoln Lightning"}
+
+	tests := map[string]bool{
+		"Lightning 12U Black":      true,
+		"LIGHTNING 14U":            true,
+		"Lightning 12U Gold":       false, // Ours
+		"Omaha Lightning 12U Gold": false, // An alias of ours
+		"lincoln  LIGHTNING":       false, // Another club, listed in Opponents
+		"Omaha Sky":                false,
+	}
+	for name, want := range tests {
+		if got := looksLikeOurs(name); got != want {
+			t.Errorf("looksLikeOurs(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestScheduleWarnsAboutLookalikeNames(t *testing.T) {
+	setupTestClub(t)
+	OpponentAliases = map[string]string{"lincoln lightning": "Lincoln Lightning"}
+	srv := fixtureServer(t)
+	team := &AllTeams[0]
+	patterns, err := parseTeamPatterns(`^lightning 12u gold - `)
+	if err != nil {
+		t.Fatal(err)
+	}
+	team.CBLPatterns = patterns
+
+	games, err := scrapeTeamSchedule(team, srv.URL+"/Public/Results/Team.aspx?IDTournament=s26&fixture=tourneymachine-lookalike.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(games) != 2 || games[0].Opponent != "Storm" || games[1].Opponent != "Hawks" {
+		t.Errorf("games = %+v, want the Storm and Hawks games", games)
+	}
+
+	// One warning per look-alike name, however it's spelled
+	if len(BuildWarnings) != 1 || !strings.Contains(BuildWarnings[0], `12U Gold page lists "Lightning 12U Black", which matches no team name or alias`) {
+		t.Errorf("warnings = %q, want one about Lightning 12U Black", BuildWarnings)
+	}
+}
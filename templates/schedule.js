@@ -22,9 +22,9 @@ function handleTimestamps() {
     if (utcTime) {
       try {
         const date = new Date(utcTime);
-        // Format in Central Time (America/Chicago)
+        // Format in the club's home timezone
         const options = {
-          timeZone: "America/Chicago",
+          timeZone: lastUpdatedEl.getAttribute("data-tz") || "America/Chicago",
           month: "numeric",
           day: "numeric",
           year: "2-digit",
@@ -107,6 +107,21 @@ function applyFilters() {
   }
 }
 
+// Clicking a tournament header shows or hides that tournament's games
+function toggleTournaments() {
+  document.querySelectorAll("tr.tournament-row").forEach(function (header) {
+    header.addEventListener("click", function () {
+      const key = header.getAttribute("data-tournament");
+      const collapsed = !header.classList.contains("collapsed");
+      document
+        .querySelectorAll('tr[data-tournament="' + key + '"]')
+        .forEach(function (row) {
+          row.classList.toggle("collapsed", collapsed);
+        });
+    });
+  });
+}
+
 function throttle(fn, context) {
   let frameId;
   return function (...args) {
@@ -158,5 +173,6 @@ function syncTableHeaders() {
 
 document.addEventListener("DOMContentLoaded", applyFilters);
 document.addEventListener("DOMContentLoaded", handleTimestamps);
+document.addEventListener("DOMContentLoaded", toggleTournaments);
 document.addEventListener("DOMContentLoaded", syncTableHeaders);
 window.addEventListener("resize", syncTableHeaders);
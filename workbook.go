@@ -0,0 +1,474 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Matches the column letters of a cell reference such as "AB12"
+var cellColumnRegex = regexp.MustCompile(`^[A-Za-z]+`)
+
+// Matches quoted text, [color]/[h] sections and escaped characters in a
+// number format, which don't say whether it's a date
+var numberFormatLiteralRegex = regexp.MustCompile(`"[^"]*"|\[[^\]]*\]|\\.`)
+
+// Matches ODS durations such as "PT18H30M00S"
+var odsTimeRegex = regexp.MustCompile(`^-?PT(\d+)H(\d+)M([\d.]+)S$`)
+
+// workbookSheet reads tabs from a local spreadsheet file (.xlsx or .ods) for
+// clubs that don't keep their schedule in Google Sheets. Tabs are found by
+// name, and dates and times come out in the same text form as the Google
+// export ("1/2/2006", "3:04 PM").
+type workbookSheet struct {
+	path string
+	tabs map[string][][]string
+}
+
+// openWorkbook reads every tab of a local .xlsx or .ods file
+func openWorkbook(file string) (*workbookSheet, error) {
+	archive, err := zip.OpenReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("error opening workbook: %v", err)
+	}
+	defer archive.Close()
+
+	return readWorkbook(&archive.Reader, file)
+}
+
+// readWorkbook reads every tab of an opened workbook; name's extension says
+// whether it's .xlsx or .ods
+func readWorkbook(archive *zip.Reader, name string) (*workbookSheet, error) {
+	files := make(map[string]*zip.File)
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	var tabs map[string][][]string
+	var err error
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".xlsx", ".xlsm":
+		tabs, err = readXLSX(files)
+	case ".ods":
+		tabs, err = readODS(files)
+	default:
+		return nil, fmt.Errorf("unsupported workbook %s (expected .xlsx or .ods)", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading workbook %s: %v", name, err)
+	}
+
+	return &workbookSheet{path: name, tabs: tabs}, nil
+}
+
+// ReadTab returns a tab by name, ignoring case and spacing differences
+func (w *workbookSheet) ReadTab(tab string) ([][]string, error) {
+	if rows, ok := w.tabs[tab]; ok {
+		return rows, nil
+	}
+	for name, rows := range w.tabs {
+		if nameKey(name) == nameKey(tab) {
+			return rows, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s tab in %s", tab, w.path)
+}
+
+// readZipXML decodes one XML part of a workbook
+func readZipXML(files map[string]*zip.File, name string, v any) error {
+	f, ok := files[name]
+	if !ok {
+		return fmt.Errorf("missing %s", name)
+	}
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if err := xml.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("error parsing %s: %v", name, err)
+	}
+	return nil
+}
+
+// XLSX (Office Open XML) parts we read
+
+type xlsxWorkbook struct {
+	Properties struct {
+		Date1904 bool `xml:"date1904,attr"`
+	} `xml:"workbookPr"`
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+		RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	text := t.Text
+	for _, run := range t.Runs {
+		text += run.Text
+	}
+	return text
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+type xlsxStyles struct {
+	NumFmts []struct {
+		ID   int    `xml:"numFmtId,attr"`
+		Code string `xml:"formatCode,attr"`
+	} `xml:"numFmts>numFmt"`
+	CellXfs []struct {
+		NumFmtID int `xml:"numFmtId,attr"`
+	} `xml:"cellXfs>xf"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Style  int      `xml:"s,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSX reads every worksheet of an .xlsx file as text
+func readXLSX(files map[string]*zip.File) (map[string][][]string, error) {
+	var workbook xlsxWorkbook
+	if err := readZipXML(files, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	var rels xlsxRelationships
+	if err := readZipXML(files, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+	targets := make(map[string]string)
+	for _, rel := range rels.Relationships {
+		if strings.HasPrefix(rel.Target, "/") {
+			targets[rel.ID] = strings.TrimPrefix(rel.Target, "/")
+		} else {
+			targets[rel.ID] = path.Join("xl", rel.Target)
+		}
+	}
+
+	// Shared strings and styles are optional parts
+	var shared xlsxSharedStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := readZipXML(files, "xl/sharedStrings.xml", &shared); err != nil {
+			return nil, err
+		}
+	}
+	var styles xlsxStyles
+	if _, ok := files["xl/styles.xml"]; ok {
+		if err := readZipXML(files, "xl/styles.xml", &styles); err != nil {
+			return nil, err
+		}
+	}
+	formats := make(map[int]string)
+	for _, format := range styles.NumFmts {
+		formats[format.ID] = format.Code
+	}
+
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	if workbook.Properties.Date1904 {
+		epoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	tabs := make(map[string][][]string)
+	for _, sheet := range workbook.Sheets {
+		var worksheet xlsxWorksheet
+		if err := readZipXML(files, targets[sheet.RID], &worksheet); err != nil {
+			return nil, err
+		}
+
+		var rows [][]string
+		for _, row := range worksheet.Rows {
+			var record []string
+			for i, cell := range row.Cells {
+				column := i
+				if letters := cellColumnRegex.FindString(cell.Ref); letters != "" {
+					column = columnIndex(letters)
+				}
+
+				value := cell.Value
+				switch cell.Type {
+				case "s":
+					if index, err := strconv.Atoi(value); err == nil && index < len(shared.Items) {
+						value = shared.Items[index].String()
+					}
+				case "inlineStr":
+					value = cell.Inline.String()
+				case "b":
+					value = map[string]string{"1": "TRUE", "0": "FALSE"}[value]
+				case "d":
+					if t, err := time.Parse("2006-01-02T15:04:05", value); err == nil {
+						value = formatSheetDateTime(t, true, t.Hour() != 0 || t.Minute() != 0)
+					}
+				case "", "n":
+					if cell.Style < len(styles.CellXfs) {
+						value = formatXLSXNumber(value, styles.CellXfs[cell.Style].NumFmtID, formats, epoch)
+					}
+				}
+
+				for len(record) < column {
+					record = append(record, "")
+				}
+				if column == len(record) {
+					record = append(record, value)
+				}
+			}
+			if !blankRow(record) {
+				rows = append(rows, record)
+			}
+		}
+		tabs[sheet.Name] = rows
+	}
+
+	return tabs, nil
+}
+
+// columnIndex converts column letters to a zero-based index ("A" is 0, "AA" is 26)
+func columnIndex(letters string) int {
+	index := 0
+	for _, letter := range strings.ToUpper(letters) {
+		index = index*26 + int(letter-'A'+1)
+	}
+	return index - 1
+}
+
+// formatXLSXNumber shows a number cell the way the schedule columns expect:
+// dates and times as text, other numbers as stored
+func formatXLSXNumber(value string, formatID int, formats map[int]string, epoch time.Time) string {
+	serial, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+
+	var hasDate, hasClock bool
+	switch {
+	case formatID >= 14 && formatID <= 17, formatID >= 27 && formatID <= 36, formatID >= 50 && formatID <= 58:
+		hasDate = true
+	case formatID >= 18 && formatID <= 21, formatID >= 45 && formatID <= 47:
+		hasClock = true
+	case formatID == 22:
+		hasDate, hasClock = true, true
+	default:
+		code, ok := formats[formatID]
+		if !ok {
+			return value
+		}
+		code = strings.ToLower(numberFormatLiteralRegex.ReplaceAllString(code, ""))
+		hasDate = strings.ContainsAny(code, "dy")
+		hasClock = strings.ContainsAny(code, "hs")
+	}
+	if !hasDate && !hasClock {
+		return value
+	}
+
+	seconds := math.Round(serial * 24 * 60 * 60)
+	t := epoch.Add(time.Duration(seconds) * time.Second)
+	return formatSheetDateTime(t, hasDate, hasClock)
+}
+
+// formatSheetDateTime renders dates and times like the Google export does
+func formatSheetDateTime(t time.Time, hasDate, hasClock bool) string {
+	switch {
+	case hasDate && hasClock:
+		return t.Format("1/2/2006 3:04 PM")
+	case hasClock:
+		return t.Format("3:04 PM")
+	}
+	return t.Format("1/2/2006")
+}
+
+// blankRow reports whether a row has no text at all
+func blankRow(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// readODS reads every table of an OpenDocument spreadsheet as text. Cells
+// and rows can be repeated (LibreOffice pads tables to their full width this
+// way), so blank repeats are only expanded when something follows them.
+func readODS(files map[string]*zip.File) (map[string][][]string, error) {
+	f, ok := files["content.xml"]
+	if !ok {
+		return nil, fmt.Errorf("missing content.xml")
+	}
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	tabs := make(map[string][][]string)
+	decoder := xml.NewDecoder(r)
+
+	var tabName string
+	var rows [][]string
+	var record []string
+	var rowRepeat, pendingCells int
+
+	// The cell being read
+	var inCell bool
+	var cellRepeat int
+	var cellValue string
+	var cellText []string
+	var paragraph *strings.Builder
+	var inAnnotation bool // Comments hold paragraphs too, which aren't cell text
+
+	attr := func(element xml.StartElement, name string) string {
+		for _, a := range element.Attr {
+			if a.Name.Local == name {
+				return a.Value
+			}
+		}
+		return ""
+	}
+	repeat := func(element xml.StartElement, name string) int {
+		if n, err := strconv.Atoi(attr(element, name)); err == nil && n > 0 {
+			return n
+		}
+		return 1
+	}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing content.xml: %v", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "table":
+				tabName, rows = attr(t, "name"), nil
+			case "table-row":
+				record, pendingCells = nil, 0
+				rowRepeat = repeat(t, "number-rows-repeated")
+			case "table-cell", "covered-table-cell":
+				inCell, cellText = true, nil
+				cellRepeat = repeat(t, "number-columns-repeated")
+				cellValue = odsTypedValue(attr(t, "value-type"), attr(t, "date-value"), attr(t, "time-value"), attr(t, "boolean-value"))
+			case "annotation":
+				inAnnotation = true
+			case "p":
+				if inCell && !inAnnotation {
+					paragraph = &strings.Builder{}
+				}
+			case "s":
+				if paragraph != nil {
+					paragraph.WriteString(strings.Repeat(" ", repeat(t, "c")))
+				}
+			case "tab":
+				if paragraph != nil {
+					paragraph.WriteString("\t")
+				}
+			case "line-break":
+				if paragraph != nil {
+					paragraph.WriteString("\n")
+				}
+			}
+
+		case xml.CharData:
+			if paragraph != nil {
+				paragraph.Write(t)
+			}
+
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "annotation":
+				inAnnotation = false
+			case "p":
+				if paragraph != nil {
+					cellText = append(cellText, paragraph.String())
+					paragraph = nil
+				}
+			case "table-cell", "covered-table-cell":
+				value := cellValue
+				if value == "" {
+					value = strings.Join(cellText, "\n")
+				}
+				if value == "" {
+					pendingCells += cellRepeat
+				} else {
+					for ; pendingCells > 0; pendingCells-- {
+						record = append(record, "")
+					}
+					for i := 0; i < cellRepeat; i++ {
+						record = append(record, value)
+					}
+				}
+				inCell = false
+			case "table-row":
+				if !blankRow(record) {
+					for i := 0; i < rowRepeat; i++ {
+						rows = append(rows, append([]string(nil), record...))
+					}
+				}
+			case "table":
+				tabs[tabName] = rows
+			}
+		}
+	}
+
+	return tabs, nil
+}
+
+// odsTypedValue renders date, time and boolean cells from their stored
+// values rather than their locale-dependent display text; other types use
+// the display text (returned as "")
+func odsTypedValue(valueType, dateValue, timeValue, booleanValue string) string {
+	switch valueType {
+	case "date":
+		for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"} {
+			if t, err := time.Parse(layout, strings.SplitN(dateValue, ".", 2)[0]); err == nil {
+				return formatSheetDateTime(t, true, t.Hour() != 0 || t.Minute() != 0)
+			}
+		}
+	case "time":
+		if match := odsTimeRegex.FindStringSubmatch(timeValue); match != nil {
+			hours, _ := strconv.Atoi(match[1])
+			minutes, _ := strconv.Atoi(match[2])
+			t := time.Date(2000, 1, 1, hours%24, minutes, 0, 0, time.UTC)
+			return formatSheetDateTime(t, false, true)
+		}
+	case "boolean":
+		return strings.ToUpper(booleanValue)
+	}
+	return ""
+}
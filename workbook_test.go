@@ -0,0 +1,125 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOpenWorkbook(t *testing.T) {
+	tests := []struct {
+		file string
+		tab  string
+		want [][]string
+	}{
+		{"testdata/workbook.xlsx", "Games", [][]string{
+			{"Date", "Time", "Opponent", "Score", "Notes"},
+			// Custom date and time formats, a rich shared string, an inline rich string
+			{"10/18/2025", "9:00 AM", "Omaha Sky", "40", "Bring snacks"},
+			// Cells B and D are missing; E is a boolean
+			{"10/19/2025", "", "Storm", "", "TRUE"},
+			// Blank row 4 is skipped; built-in date-time and time formats, a
+			// cell without a reference, a "days" literal that isn't a date
+			{"10/19/2025 1:30 PM", "1:30 PM", "TBD", "3"},
+		}},
+		{"testdata/workbook.xlsx", "Team Sources", [][]string{{"Team", "URL"}, {"12U Gold", "https://example.com/12u"}}},
+		// Dates count from 1904 in workbooks saved that way
+		{"testdata/workbook-1904.xlsx", "Games", [][]string{{"Date", "Time"}, {"10/18/2025", "10/18/2025 6:00 PM"}}},
+		{"testdata/workbook.ods", "Games", [][]string{
+			{"Date", "Time", "Opponent", "Home", "Notes"},
+			// Typed values win over display text; the comment isn't cell text
+			{"10/18/2025", "9:00 AM", "Omaha  Sky", "TRUE", "Line one\nLine\ntwo\tend"},
+			// Blank repeated cells are expanded only before a value
+			{"10/19/2025 1:30 PM", "", "", "1"},
+			// Repeated rows and cells, and a covered (merged) cell
+			{"TBD", "TBD", "", "6:30 PM"},
+			{"TBD", "TBD", "", "6:30 PM"},
+		}},
+		{"testdata/workbook.ods", "Notes", [][]string{{"Date", "Text"}, {"11/1/2025", "No practice"}}},
+	}
+	for _, test := range tests {
+		workbook, err := openWorkbook(test.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows, err := workbook.ReadTab(test.tab)
+		if err != nil {
+			t.Errorf("%s: %v", test.file, err)
+			continue
+		}
+		if !reflect.DeepEqual(rows, test.want) {
+			t.Errorf("%s %s:\n got %q\nwant %q", test.file, test.tab, rows, test.want)
+		}
+	}
+}
+
+func TestWorkbookReadTab(t *testing.T) {
+	workbook, err := openWorkbook("testdata/workbook.xlsx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tab := range []string{"games", "GAMES", "TeamSources", "team sources"} {
+		if _, err := workbook.ReadTab(tab); err != nil {
+			t.Errorf("ReadTab(%q): %v", tab, err)
+		}
+	}
+	if _, err := workbook.ReadTab("Jerseys"); err == nil || !strings.Contains(err.Error(), "no Jerseys tab") {
+		t.Errorf("ReadTab(Jerseys) error = %v", err)
+	}
+
+	if _, err := openWorkbook("testdata/league-feed.ics"); err == nil {
+		t.Error("expected an error for a file that isn't a workbook")
+	}
+	if _, err := openWorkbook("testdata/missing.xlsx"); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestFormatXLSXNumber(t *testing.T) {
+	epoch1900 := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	epoch1904 := time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	formats := map[int]string{
+		164: "m/d/yyyy",
+		165: "h:mm AM/PM",
+		166: `"days "0`,
+		167: `[$-409]dddd, mmmm d`,
+		168: `[h]:mm`,
+		169: `0.0\d`,
+	}
+
+	tests := []struct {
+		value    string
+		formatID int
+		epoch    time.Time
+		want     string
+	}{
+		{"45948", 14, epoch1900, "10/18/2025"},
+		{"45948", 0, epoch1900, "45948"}, // General
+		{"45948.75", 22, epoch1900, "10/18/2025 6:00 PM"},
+		{"0.375", 18, epoch1900, "9:00 AM"},
+		{"0.999995", 20, epoch1900, "12:00 AM"}, // Rounds to the nearest second
+		{"45948", 164, epoch1900, "10/18/2025"},
+		{"0.8125", 165, epoch1900, "7:30 PM"},
+		{"44486", 164, epoch1904, "10/18/2025"},
+		{"3", 166, epoch1900, "3"},
+		{"45948", 167, epoch1900, "10/18/2025"},
+		{"0.5", 168, epoch1900, "0.5"},     // Elapsed hours are a duration, not a time of day
+		{"2.5", 169, epoch1900, "2.5"},     // Escaped \d is a literal
+		{"45948", 200, epoch1900, "45948"}, // Unknown custom format
+		{"TBD", 14, epoch1900, "TBD"},
+	}
+	for _, test := range tests {
+		if got := formatXLSXNumber(test.value, test.formatID, formats, test.epoch); got != test.want {
+			t.Errorf("formatXLSXNumber(%q, %d) = %q, want %q", test.value, test.formatID, got, test.want)
+		}
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	for letters, want := range map[string]int{"A": 0, "b": 1, "Z": 25, "AA": 26, "AZ": 51, "BA": 52} {
+		if got := columnIndex(letters); got != want {
+			t.Errorf("columnIndex(%q) = %d, want %d", letters, got, want)
+		}
+	}
+}